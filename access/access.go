@@ -0,0 +1,100 @@
+// Package access resolves the acting user of a request and checks the
+// user_profile role and branch assignments before a service performs an
+// operation on their behalf.
+package access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+// Role is a user_profile.user_role value.
+type Role string
+
+const (
+	RoleAdmin          Role = "admin"
+	RoleAdminReadOnly  Role = "adminReadOnly"
+	RoleBranchManager  Role = "branchManager"
+	RoleBranchReadOnly Role = "branchReadOnly"
+	RoleSales          Role = "sales"
+)
+
+var (
+	// ErrUnknownUser is returned when the user is not a member of the organization.
+	ErrUnknownUser = errors.New("access: user is not a member of the organization")
+	// ErrForbidden is returned when the user's role or branches do not allow the operation.
+	ErrForbidden = errors.New("access: operation not permitted for this user")
+)
+
+// Actor is a user acting within one organization.
+type Actor struct {
+	UserProfileID  uuid.UUID
+	Email          string
+	FullName       string
+	Role           Role
+	OrganizationID uuid.UUID
+	BranchUuids    []uuid.UUID
+}
+
+// LoadActor looks up the profile, role and branch assignments of a user in
+// an organization.
+func LoadActor(ctx context.Context, q *generated.Queries, userProfileID, organizationID uuid.UUID) (Actor, error) {
+	profile, err := q.GetUserProfile(ctx, userProfileID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Actor{}, ErrUnknownUser
+	}
+	if err != nil {
+		return Actor{}, fmt.Errorf("get user profile: %w", err)
+	}
+	membership, err := q.GetUserOrganizationBranch(ctx, generated.GetUserOrganizationBranchParams{
+		UserProfileID:  userProfileID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Actor{}, ErrUnknownUser
+	}
+	if err != nil {
+		return Actor{}, fmt.Errorf("get user organization branches: %w", err)
+	}
+	return Actor{
+		UserProfileID:  userProfileID,
+		Email:          profile.UserEmail,
+		FullName:       profile.FullName,
+		Role:           Role(profile.UserRole.String),
+		OrganizationID: organizationID,
+		BranchUuids:    membership.BranchUuids,
+	}, nil
+}
+
+// HasRole reports whether the actor has one of roles.
+func (a Actor) HasRole(roles ...Role) bool {
+	return slices.Contains(roles, a.Role)
+}
+
+// CanAccessBranch reports whether the actor may work in a branch.
+// Organization-wide roles reach every branch; the others are limited to the
+// branches assigned to them.
+func (a Actor) CanAccessBranch(branchUuid uuid.UUID) bool {
+	if a.HasRole(RoleAdmin, RoleAdminReadOnly) {
+		return true
+	}
+	return slices.Contains(a.BranchUuids, branchUuid)
+}
+
+// RequireBranchRole returns ErrForbidden unless the actor has one of roles and
+// can access the branch.
+func (a Actor) RequireBranchRole(branchUuid uuid.UUID, roles ...Role) error {
+	if !a.HasRole(roles...) {
+		return fmt.Errorf("role %q: %w", a.Role, ErrForbidden)
+	}
+	if !a.CanAccessBranch(branchUuid) {
+		return fmt.Errorf("branch %s: %w", branchUuid, ErrForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Package alerts warns when products fall to their reorder point.
+//
+// Scan raises an alert for each product at or below its reorder_point and
+// resolves the alerts of products that have recovered. At most one alert is
+// open per product, so a product that stays low is notified once; it is
+// notified again only after it recovers and dips again. Notifications are
+// sent after the scan has committed, through each of the service's channels.
+// Deliveries are recorded per channel and an alert is marked notified once
+// every channel has delivered it; channels that fail are retried alone by the
+// next scan.
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+var (
+	// ErrProductNotFound is returned when a product does not exist in the organization.
+	ErrProductNotFound = errors.New("alerts: product not found")
+	// ErrInvalidLevels is returned when reorder levels are negative.
+	ErrInvalidLevels = errors.New("alerts: reorder levels must not be negative")
+)
+
+// managerRoles may change reorder levels.
+var managerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// readerRoles may list low stock.
+var readerRoles = []access.Role{access.RoleAdmin, access.RoleAdminReadOnly, access.RoleBranchManager, access.RoleBranchReadOnly}
+
+// Alert is a product that fell to its reorder point.
+type Alert struct {
+	AlertID           uuid.UUID       `json:"alert_id"`
+	ProductID         uuid.UUID       `json:"product_id"`
+	UniqueName        string          `json:"unique_name"`
+	ProductName       string          `json:"product_name"`
+	BranchUuid        uuid.UUID       `json:"branch_uuid"`
+	BranchName        string          `json:"branch_name"`
+	OrganizationID    uuid.UUID       `json:"organization_id"`
+	RemainingQuantity decimal.Decimal `json:"remaining_quantity"`
+	ReorderPoint      decimal.Decimal `json:"reorder_point"`
+	ReorderQuantity   decimal.Decimal `json:"reorder_quantity"`
+	RaisedAt          time.Time       `json:"raised_at"`
+}
+
+// ScanResult counts the alerts changed by a scan.
+type ScanResult struct {
+	Resolved int64
+	Raised   int64
+	Notified int
+}
+
+// Service manages reorder levels and raises low-stock alerts.
+type Service struct {
+	db       *store.DB
+	channels Channels
+}
+
+// NewService returns an alerts Service that delivers alerts through every
+// channel.
+func NewService(db *store.DB, channels Channels) *Service {
+	return &Service{db: db, channels: channels}
+}
+
+// SetReorderLevels sets the reorder point and quantity of a product. A
+// reorder point of zero turns alerts off for the product.
+func (s *Service) SetReorderLevels(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, point, quantity decimal.Decimal) (generated.Product, error) {
+	if point.IsNegative() || quantity.IsNegative() {
+		return generated.Product{}, ErrInvalidLevels
+	}
+	var product generated.Product
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		branchUuid, err := q.GetAlertProductBranch(ctx, generated.GetAlertProductBranchParams{
+			ProductID:      productID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get product branch: %w", err)
+		}
+		if err := actor.RequireBranchRole(branchUuid, managerRoles...); err != nil {
+			return err
+		}
+		product, err = q.UpdateProductReorderLevels(ctx, generated.UpdateProductReorderLevelsParams{
+			ReorderPoint:    point,
+			ReorderQuantity: quantity,
+			ProductID:       productID,
+			OrganizationID:  organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update reorder levels: %w", err)
+		}
+		return nil
+	})
+	return product, err
+}
+
+// LowStock returns the products of an organization at or below their
+// reorder point, optionally limited to one branch. Branch users only see
+// the branches assigned to them.
+func (s *Service) LowStock(ctx context.Context, organizationID, userProfileID uuid.UUID, branchUuid uuid.NullUUID) ([]generated.ListLowStockProductsRow, error) {
+	q := s.db.Queries()
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasRole(readerRoles...) {
+		return nil, fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	if branchUuid.Valid && !actor.CanAccessBranch(branchUuid.UUID) {
+		return nil, fmt.Errorf("branch %s: %w", branchUuid.UUID, access.ErrForbidden)
+	}
+	products, err := q.ListLowStockProducts(ctx, generated.ListLowStockProductsParams{
+		OrganizationID: organizationID,
+		BranchUuid:     branchUuid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list low stock products: %w", err)
+	}
+	visible := products[:0]
+	for _, p := range products {
+		if actor.CanAccessBranch(p.BranchUuid) {
+			visible = append(visible, p)
+		}
+	}
+	return visible, nil
+}
+
+// Scan resolves recovered alerts, raises alerts for products that have
+// fallen to their reorder point and notifies every pending alert through the
+// channels that have not delivered it yet. Notification errors are returned
+// after all pending alerts were tried.
+func (s *Service) Scan(ctx context.Context) (ScanResult, error) {
+	var result ScanResult
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		result.Resolved, err = q.ResolveRecoveredStockAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("resolve stock alerts: %w", err)
+		}
+		result.Raised, err = q.RaiseStockAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("raise stock alerts: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// Notifiers may be slow or unreachable, so no transaction or lock is held
+	// while they run. Scans running side by side may deliver an alert twice.
+	pending, err := s.db.Queries().ListPendingStockAlerts(ctx)
+	if err != nil {
+		return result, fmt.Errorf("list pending stock alerts: %w", err)
+	}
+	names := slices.Sorted(maps.Keys(s.channels))
+	var notifyErrs []error
+	for _, p := range pending {
+		alert := Alert(p)
+		delivered, err := s.db.Queries().ListStockAlertDeliveries(ctx, alert.AlertID)
+		if err != nil {
+			return result, fmt.Errorf("list deliveries of alert %s: %w", alert.AlertID, err)
+		}
+		failed := false
+		for _, name := range names {
+			if slices.Contains(delivered, name) {
+				continue
+			}
+			if err := s.channels[name].Notify(ctx, alert); err != nil {
+				notifyErrs = append(notifyErrs, fmt.Errorf("notify alert %s via %s: %w", alert.AlertID, name, err))
+				failed = true
+				continue
+			}
+			err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+				return q.InsertStockAlertDelivery(ctx, generated.InsertStockAlertDeliveryParams{
+					AlertID: alert.AlertID,
+					Channel: name,
+				})
+			})
+			if err != nil {
+				return result, fmt.Errorf("record delivery of alert %s via %s: %w", alert.AlertID, name, err)
+			}
+		}
+		if failed {
+			continue
+		}
+		err = s.db.WithTx(ctx, func(q *generated.Queries) error {
+			return q.MarkStockAlertNotified(ctx, alert.AlertID)
+		})
+		if err != nil {
+			return result, fmt.Errorf("mark alert %s notified: %w", alert.AlertID, err)
+		}
+		result.Notified++
+	}
+	return result, errors.Join(notifyErrs...)
+}
+
+// Run scans every interval until ctx is cancelled. Scan errors are logged
+// and the next scan is attempted as usual.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := s.Scan(ctx)
+		if err != nil {
+			log.Printf("stock alert scan: %v", err)
+		} else if result.Raised > 0 || result.Resolved > 0 {
+			log.Printf("stock alert scan: raised %d, resolved %d, notified %d", result.Raised, result.Resolved, result.Notified)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
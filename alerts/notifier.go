@@ -0,0 +1,98 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Notifier delivers a low-stock alert.
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// Message is the one-line description of an alert used by the notifiers.
+func (a Alert) Message() string {
+	return fmt.Sprintf("Low stock: %s (%s) at %s has %s left, reorder point %s, reorder %s",
+		a.ProductName, a.UniqueName, a.BranchName,
+		a.RemainingQuantity.String(), a.ReorderPoint.String(), a.ReorderQuantity.String())
+}
+
+// LogNotifier writes alerts to a logger.
+type LogNotifier struct {
+	Logger *log.Logger
+}
+
+func (n LogNotifier) Notify(_ context.Context, alert Alert) error {
+	logger := n.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Println(alert.Message())
+	return nil
+}
+
+// WebhookNotifier posts each alert as JSON to a URL. Any response other than
+// 2xx is an error, so the alert is retried on the next scan.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("encode alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailNotifier composes an email for each alert and writes it to Out. It
+// stands in for a mail transport until one is configured.
+type EmailNotifier struct {
+	From string
+	To   []string
+	Out  io.Writer
+}
+
+func (n EmailNotifier) Notify(_ context.Context, alert Alert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&b, "Date: %s\r\n", alert.RaisedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Subject: Low stock: %s at %s\r\n", alert.ProductName, alert.BranchName)
+	b.WriteString("\r\n")
+	b.WriteString(alert.Message())
+	b.WriteString("\r\n")
+	_, err := io.WriteString(n.Out, b.String())
+	return err
+}
+
+// Channels names the notifiers alerts are delivered through. Scan records
+// each delivery by channel name, so a channel that fails is retried alone
+// and the others do not repeat the alert. Names must stay the same between
+// scans.
+type Channels map[string]Notifier
@@ -0,0 +1,249 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+const (
+	// DefaultPageSize is used when a filter does not set a page size.
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page Search returns.
+	MaxPageSize = 500
+	// exportPageSize is the number of rows read per query while exporting.
+	exportPageSize = 1000
+)
+
+// ErrUnknownFormat is returned when exporting to a format other than csv or jsonl.
+var ErrUnknownFormat = errors.New("audit: unknown export format")
+
+// readerRoles may read the activity log of their organization.
+var readerRoles = []access.Role{access.RoleAdmin, access.RoleAdminReadOnly}
+
+// Filter selects activity rows of an organization. Zero-valued fields do
+// not filter. Resource matches rows with any resource element equal to it,
+// such as a table name or a row id.
+type Filter struct {
+	OrganizationID uuid.UUID
+	Identity       string
+	Operation      generated.OperationType
+	Resource       string
+	Status         sql.NullBool
+	From           time.Time
+	To             time.Time
+	// After is the cursor of the previous page; rows are returned in seq
+	// order starting after it.
+	After    int64
+	PageSize int
+}
+
+// Page is one page of search results. NextCursor is the After value of the
+// next page, or 0 when there are no more rows.
+type Page struct {
+	Entries    []generated.Activity `json:"entries"`
+	NextCursor int64                `json:"next_cursor"`
+}
+
+// Service searches and exports the activity log.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns an audit Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Search returns a page of activity rows matching f. Only admin and
+// adminReadOnly users of f.OrganizationID may search.
+func (s *Service) Search(ctx context.Context, userProfileID uuid.UUID, f Filter) (Page, error) {
+	q := s.db.Queries()
+	if _, err := requireReader(ctx, q, userProfileID, f.OrganizationID); err != nil {
+		return Page{}, err
+	}
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+
+	// Read one row past the page to know whether another page follows.
+	entries, err := q.SearchActivity(ctx, searchParams(f, f.After, pageSize+1))
+	if err != nil {
+		return Page{}, fmt.Errorf("search activity: %w", err)
+	}
+	page := Page{Entries: entries}
+	if len(entries) > pageSize {
+		page.Entries = entries[:pageSize]
+		page.NextCursor = page.Entries[pageSize-1].Seq
+	}
+	return page, nil
+}
+
+// Export writes every activity row matching f to w in format, ignoring the
+// cursor and page size of f. The export itself is recorded afterwards as
+// a read activity, failed if any row could not be written. Only admin and adminReadOnly users of f.OrganizationID may
+// export.
+func (s *Service) Export(ctx context.Context, userProfileID uuid.UUID, f Filter, format Format, w io.Writer) (int, error) {
+	if format != FormatCSV && format != FormatJSONL {
+		return 0, fmt.Errorf("%q: %w", format, ErrUnknownFormat)
+	}
+	q := s.db.Queries()
+	actor, err := requireReader(ctx, q, userProfileID, f.OrganizationID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Recorded once every row is written, so the export never contains its
+	// own entry and a failed export is logged as such.
+	written, err := export(ctx, q, f, format, w)
+	recordErr := q.InsertActivity(ctx, generated.InsertActivityParams{
+		Identity:       actor.Email,
+		Operation:      generated.OperationTypeRead,
+		Resource:       []string{"activity", "export", string(format)},
+		OldValue:       []string{},
+		NewValue:       filterValues(f),
+		Status:         err == nil,
+		OrganizationID: f.OrganizationID,
+	})
+	if recordErr != nil {
+		return written, errors.Join(err, fmt.Errorf("record export activity: %w", recordErr))
+	}
+	return written, err
+}
+
+// export writes every activity row matching f to w and flushes it.
+func export(ctx context.Context, q *generated.Queries, f Filter, format Format, w io.Writer) (int, error) {
+	var write func(generated.Activity) error
+	var flush func() error
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return 0, err
+		}
+		write = func(a generated.Activity) error { return cw.Write(csvRecord(a)) }
+		flush = func() error { cw.Flush(); return cw.Error() }
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(a generated.Activity) error { return enc.Encode(a) }
+		flush = func() error { return nil }
+	}
+
+	written := 0
+	var after int64
+	for {
+		entries, err := q.SearchActivity(ctx, searchParams(f, after, exportPageSize))
+		if err != nil {
+			return written, fmt.Errorf("search activity: %w", err)
+		}
+		for _, a := range entries {
+			if err := write(a); err != nil {
+				return written, fmt.Errorf("write activity %d: %w", a.Seq, err)
+			}
+			written++
+			after = a.Seq
+		}
+		if len(entries) < exportPageSize {
+			return written, flush()
+		}
+	}
+}
+
+// requireReader loads the acting user and checks they may read the
+// organization's activity log.
+func requireReader(ctx context.Context, q *generated.Queries, userProfileID, organizationID uuid.UUID) (access.Actor, error) {
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return actor, err
+	}
+	if !actor.HasRole(readerRoles...) {
+		return actor, fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	return actor, nil
+}
+
+func searchParams(f Filter, after int64, pageSize int) generated.SearchActivityParams {
+	return generated.SearchActivityParams{
+		OrganizationID: f.OrganizationID,
+		AfterSeq:       after,
+		Identity:       sql.NullString{String: f.Identity, Valid: f.Identity != ""},
+		Operation:      generated.NullOperationType{OperationType: f.Operation, Valid: f.Operation != ""},
+		Resource:       sql.NullString{String: f.Resource, Valid: f.Resource != ""},
+		Status:         f.Status,
+		FromTime:       sql.NullTime{Time: f.From, Valid: !f.From.IsZero()},
+		ToTime:         sql.NullTime{Time: f.To, Valid: !f.To.IsZero()},
+		PageSize:       int32(pageSize),
+	}
+}
+
+// filterValues describes f for the export's activity row.
+func filterValues(f Filter) []string {
+	values := []string{}
+	add := func(name, value string) {
+		if value != "" {
+			values = append(values, name+"="+value)
+		}
+	}
+	add("identity", f.Identity)
+	add("operation", string(f.Operation))
+	add("resource", f.Resource)
+	if f.Status.Valid {
+		add("status", strconv.FormatBool(f.Status.Bool))
+	}
+	if !f.From.IsZero() {
+		add("from", f.From.Format(time.RFC3339))
+	}
+	if !f.To.IsZero() {
+		add("to", f.To.Format(time.RFC3339))
+	}
+	return values
+}
+
+var csvHeader = []string{"seq", "id", "time", "identity", "operation", "resource", "old_value", "new_value", "status", "organization_id", "row_hash"}
+
+// csvRecord encodes a row for CSV export. Array columns are written as JSON
+// arrays so that elements containing commas survive the round trip.
+func csvRecord(a generated.Activity) []string {
+	return []string{
+		strconv.FormatInt(a.Seq, 10),
+		a.ID.String(),
+		a.Time.UTC().Format(time.RFC3339Nano),
+		a.Identity,
+		string(a.Operation),
+		jsonArray(a.Resource),
+		jsonArray(a.OldValue),
+		jsonArray(a.NewValue),
+		strconv.FormatBool(a.Status),
+		a.OrganizationID.String(),
+		a.RowHash,
+	}
+}
+
+func jsonArray(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
@@ -0,0 +1,348 @@
+// Package bulkload copies historical purchases, sales and sales groups of an
+// organization, migrated from another system, into the database with COPY.
+//
+// Input is CSV with a header row naming table columns, as written by the
+// exporter. Rows are validated one by one and their references to branches,
+// users, partners, shifts, products and groups are checked a batch at a time
+// before the batch is copied, so a bad row is reported and skipped instead of
+// aborting the COPY. Each batch is committed together with the load's
+// checkpoint in bulk_loads; loading the same input again under the same name
+// continues after the last committed batch.
+//
+// Quantities entered in another unit must convert to the product's unit,
+// and sales groups must use a payment method of the organization, with a
+// partner when the method is credit. Each loaded sales group with a payment
+// method other than credit is settled by one tender of its full amount,
+// copied with it; credit groups get no tender, since credit tenders count
+// towards partner balances.
+//
+// Loaded rows are history: they do not move stock, create cost layers or
+// post partner balances, and they are recorded in activity as one entry per
+// batch rather than per row.
+package bulkload
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+const (
+	// DefaultBatchSize is the number of rows committed per transaction when
+	// a Request leaves BatchSize at zero.
+	DefaultBatchSize = 5000
+	// MaxReportedErrors bounds the row errors kept in a Result; further
+	// rejected rows are only counted.
+	MaxReportedErrors = 1000
+)
+
+var (
+	// ErrUnknownTable is returned when loading a table other than purchases, sales or sales_group.
+	ErrUnknownTable = errors.New("bulkload: unknown table")
+	// ErrNoName is returned when a load has no name.
+	ErrNoName = errors.New("bulkload: load name required")
+	// ErrEmptyFile is returned when the input has no header row.
+	ErrEmptyFile = errors.New("bulkload: input has no header row")
+	// ErrUnknownColumn is returned when a header names no column of the table.
+	ErrUnknownColumn = errors.New("bulkload: unknown column")
+	// ErrDuplicateColumn is returned when two headers name the same column.
+	ErrDuplicateColumn = errors.New("bulkload: column appears twice")
+	// ErrMissingColumn is returned when a required column has no header.
+	ErrMissingColumn = errors.New("bulkload: required column missing")
+	// ErrTableMismatch is returned when a load name was started for another table.
+	ErrTableMismatch = errors.New("bulkload: load was started for another table")
+	// ErrLoadCompleted is returned when a load has already read all of its input.
+	ErrLoadCompleted = errors.New("bulkload: load already completed")
+	// ErrShortInput is returned when the input ends before the load's checkpoint.
+	ErrShortInput = errors.New("bulkload: input ends before the checkpoint")
+	// ErrCheckpointMoved is returned when another run advanced the load meanwhile.
+	ErrCheckpointMoved = errors.New("bulkload: checkpoint advanced by another run")
+
+	// ErrRequired is reported for a row with an empty required value.
+	ErrRequired = errors.New("bulkload: value required")
+	// ErrInvalidUUID is reported for a value that is not a UUID.
+	ErrInvalidUUID = errors.New("bulkload: not a uuid")
+	// ErrInvalidDecimal is reported for a value that is not a decimal number.
+	ErrInvalidDecimal = errors.New("bulkload: not a decimal number")
+	// ErrInvalidTime is reported for a value that is not a date or timestamp.
+	ErrInvalidTime = errors.New("bulkload: not a date or timestamp")
+	// ErrInvalidValue is reported for a value outside of a column's allowed values.
+	ErrInvalidValue = errors.New("bulkload: value not allowed")
+	// ErrNegative is reported for a negative price, amount or quantity.
+	ErrNegative = errors.New("bulkload: value must not be negative")
+	// ErrNotPositive is reported for a quantity of zero or less.
+	ErrNotPositive = errors.New("bulkload: value must be positive")
+	// ErrFutureDate is reported for a date after the load.
+	ErrFutureDate = errors.New("bulkload: date is in the future")
+	// ErrOtherOrganization is reported for an organization_id other than the load's.
+	ErrOtherOrganization = errors.New("bulkload: row belongs to another organization")
+	// ErrDuplicateID is reported for an id already stored or used by an earlier row.
+	ErrDuplicateID = errors.New("bulkload: id already exists")
+	// ErrUnknownReference is reported for a reference not found in the organization.
+	ErrUnknownReference = errors.New("bulkload: referenced row not found in the organization")
+	// ErrBranchMismatch is reported for a reference to a row of another branch.
+	ErrBranchMismatch = errors.New("bulkload: referenced row belongs to another branch")
+	// ErrQuantityMismatch is reported for a quantity in the product's unit
+	// that is not the entered quantity converted to it.
+	ErrQuantityMismatch = errors.New("bulkload: quantity does not match the entered unit and quantity")
+)
+
+// Request describes a bulk load into a table. Name identifies the load
+// within the organization, typically the input file name; loading again
+// under the same name resumes from its checkpoint. BatchSize is the number
+// of rows committed per transaction.
+type Request struct {
+	OrganizationID uuid.UUID
+	UserProfileID  uuid.UUID
+	Name           string
+	Table          generated.BulkLoadTable
+	BatchSize      int
+}
+
+// RowError is a problem with one value of a row. Line is the line of the
+// row in the input, the header being line 1.
+type RowError struct {
+	Line   int    `json:"line"`
+	Column string `json:"column"`
+	Value  string `json:"value"`
+	Err    error  `json:"-"`
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d, %s %q: %v", e.Line, e.Column, e.Value, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// Result is the outcome of a load run. Skipped counts the rows committed by
+// earlier runs and passed over; Loaded and Rejected count the rows of this
+// run. Load is the checkpoint after the last committed batch.
+type Result struct {
+	Load     generated.BulkLoad `json:"load"`
+	Skipped  int64              `json:"skipped"`
+	Loaded   int64              `json:"loaded"`
+	Rejected int64              `json:"rejected"`
+	Errors   []RowError         `json:"errors"`
+}
+
+func (r *Result) report(errs []RowError) {
+	r.Rejected += int64(len(errs))
+	if room := MaxReportedErrors - len(r.Errors); room > 0 {
+		r.Errors = append(r.Errors, errs[:min(room, len(errs))]...)
+	}
+}
+
+// Service runs bulk loads.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a bulkload Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Load copies the rows of r into req.Table, skipping the rows committed by
+// earlier runs of the same load. Only admin users may load. When a batch
+// fails, the batches before it stay committed and the load can be resumed;
+// the returned Result covers the rows committed so far.
+func (s *Service) Load(ctx context.Context, req Request, r io.Reader) (Result, error) {
+	t, ok := tables[req.Table]
+	if !ok {
+		return Result{}, fmt.Errorf("%q: %w", req.Table, ErrUnknownTable)
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return Result{}, ErrNoName
+	}
+	q := s.db.Queries()
+	actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+	if err != nil {
+		return Result{}, err
+	}
+	if !actor.HasRole(access.RoleAdmin) {
+		return Result{}, fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return Result{}, ErrEmptyFile
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("read header: %w", err)
+	}
+	columns, err := t.header(header)
+	if err != nil {
+		return Result{}, err
+	}
+
+	err = q.StartBulkLoad(ctx, generated.StartBulkLoadParams{
+		OrganizationID: req.OrganizationID,
+		LoadName:       req.Name,
+		TableName:      req.Table,
+		StartedBy:      req.UserProfileID,
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("start bulk load: %w", err)
+	}
+	load, err := q.GetBulkLoad(ctx, generated.GetBulkLoadParams{OrganizationID: req.OrganizationID, LoadName: req.Name})
+	if err != nil {
+		return Result{}, fmt.Errorf("get bulk load: %w", err)
+	}
+	if load.TableName != req.Table {
+		return Result{Load: load}, fmt.Errorf("load %q into %s: %w", req.Name, load.TableName, ErrTableMismatch)
+	}
+	if load.CompletedAt.Valid {
+		return Result{Load: load}, fmt.Errorf("load %q: %w", req.Name, ErrLoadCompleted)
+	}
+
+	result := Result{Load: load, Skipped: load.RowsRead}
+	for i := int64(0); i < load.RowsRead; i++ {
+		if _, err := cr.Read(); errors.Is(err, io.EOF) {
+			return result, fmt.Errorf("after %d of %d rows: %w", i, load.RowsRead, ErrShortInput)
+		} else if err != nil {
+			return result, fmt.Errorf("skip committed rows: %w", err)
+		}
+	}
+
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	for done := false; !done; {
+		b := t.batch(req.OrganizationID)
+		var errs []RowError
+		read := 0
+		for read < batchSize {
+			fields, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				done = true
+				break
+			}
+			if err != nil {
+				return result, fmt.Errorf("read row: %w", err)
+			}
+			read++
+			line, _ := cr.FieldPos(0)
+			if rowErr := b.add(record{line: line, columns: columns, fields: fields}); rowErr != nil {
+				errs = append(errs, *rowErr)
+			}
+		}
+		if read == 0 {
+			break
+		}
+		loaded, batchErrs, err := s.copyBatch(ctx, actor, req, t, b, read, result.Load.RowsRead)
+		if err != nil {
+			return result, err
+		}
+		result.Loaded += loaded.RowsLoaded - result.Load.RowsLoaded
+		result.Load = loaded
+		result.report(append(errs, batchErrs...))
+	}
+
+	load, err = q.CompleteBulkLoad(ctx, generated.CompleteBulkLoadParams{OrganizationID: req.OrganizationID, LoadName: req.Name})
+	if err != nil {
+		return result, fmt.Errorf("complete bulk load: %w", err)
+	}
+	result.Load = load
+	return result, nil
+}
+
+// copyBatch checks the references of a batch of read input rows, copies the
+// rows that pass and advances the checkpoint from rowsRead, in one
+// transaction. It returns the rows rejected by the reference checks.
+func (s *Service) copyBatch(ctx context.Context, actor access.Actor, req Request, t table, b batch, read int, rowsRead int64) (generated.BulkLoad, []RowError, error) {
+	tx, err := s.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return generated.BulkLoad{}, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	q := generated.New(tx)
+
+	load, err := q.LockBulkLoad(ctx, generated.LockBulkLoadParams{OrganizationID: req.OrganizationID, LoadName: req.Name})
+	if err != nil {
+		return generated.BulkLoad{}, nil, fmt.Errorf("lock bulk load: %w", err)
+	}
+	if load.RowsRead != rowsRead {
+		return load, nil, fmt.Errorf("expected %d rows, found %d: %w", rowsRead, load.RowsRead, ErrCheckpointMoved)
+	}
+	errs, err := b.check(ctx, q)
+	if err != nil {
+		return load, nil, fmt.Errorf("check references: %w", err)
+	}
+	rows := b.rows()
+	if err := copyRows(ctx, tx, string(t.name), t.columns, rows); err != nil {
+		return load, nil, err
+	}
+	if d, ok := b.(dependent); ok {
+		table, columns, dependentRows := d.dependentRows()
+		if err := copyRows(ctx, tx, table, columns, dependentRows); err != nil {
+			return load, nil, err
+		}
+	}
+
+	load, err = q.AdvanceBulkLoad(ctx, generated.AdvanceBulkLoadParams{
+		RowsLoaded:     int64(len(rows)),
+		RowsRejected:   int64(read - len(rows)),
+		OrganizationID: req.OrganizationID,
+		LoadName:       req.Name,
+	})
+	if err != nil {
+		return load, nil, fmt.Errorf("advance checkpoint: %w", err)
+	}
+	err = q.InsertActivity(ctx, generated.InsertActivityParams{
+		Identity:  actor.Email,
+		Operation: generated.OperationTypeWrite,
+		Resource:  []string{"bulk_loads", req.Name, string(req.Table)},
+		OldValue:  []string{fmt.Sprintf("rows_read=%d", rowsRead)},
+		NewValue: []string{
+			fmt.Sprintf("rows_read=%d", load.RowsRead),
+			fmt.Sprintf("rows_loaded=%d", load.RowsLoaded),
+			fmt.Sprintf("rows_rejected=%d", load.RowsRejected),
+		},
+		Status:         true,
+		OrganizationID: req.OrganizationID,
+	})
+	if err != nil {
+		return load, nil, fmt.Errorf("record bulk load activity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return load, nil, fmt.Errorf("commit batch: %w", err)
+	}
+	return load, errs, nil
+}
+
+// copyRows streams rows into the columns of a table with COPY FROM STDIN.
+func copyRows(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return fmt.Errorf("start copy into %s: %w", table, err)
+	}
+	defer stmt.Close()
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("copy into %s: %w", table, err)
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("copy into %s: %w", table, err)
+	}
+	return nil
+}
@@ -0,0 +1,729 @@
+package bulkload
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/partners"
+	"github.com/sushan531/auth-sqlc/units"
+)
+
+// table is a table rows can be loaded into. Columns are listed in COPY
+// order; every column is copied, columns without a header taking the
+// default their parser gives them.
+type table struct {
+	name     generated.BulkLoadTable
+	columns  []string
+	required []string
+	batch    func(organizationID uuid.UUID) batch
+}
+
+// batch collects the valid rows of one transaction.
+type batch interface {
+	// add parses a row, keeping it when it is valid on its own.
+	add(r record) *RowError
+	// check drops the rows whose references do not resolve within the
+	// organization, whose ids are taken or whose units do not convert, and
+	// fills in values that default from referenced rows.
+	check(ctx context.Context, q *generated.Queries) ([]RowError, error)
+	// rows returns the kept rows as values in COPY column order.
+	rows() [][]any
+}
+
+// dependent is a batch whose kept rows need rows of another table, copied
+// after its own in the same transaction.
+type dependent interface {
+	dependentRows() (table string, columns []string, rows [][]any)
+}
+
+var tables = map[generated.BulkLoadTable]table{
+	generated.BulkLoadTablePurchases: {
+		name: generated.BulkLoadTablePurchases,
+		columns: []string{"purchase_id", "purchase_group_id", "product_id", "product_name", "unit_purchase_price", "units",
+			"branch_uuid", "organization_id", "purchased_unit", "purchased_quantity"},
+		required: []string{"product_name", "unit_purchase_price", "units", "branch_uuid"},
+		batch:    func(organizationID uuid.UUID) batch { return &purchaseBatch{organizationID: organizationID} },
+	},
+	generated.BulkLoadTableSales: {
+		name: generated.BulkLoadTableSales,
+		columns: []string{"sales_id", "sales_group_id", "product_id", "quantity", "current_cost_price", "sales_price", "total",
+			"profit", "tax_rate", "taxable_amount", "tax_amount", "sold_unit", "sold_quantity"},
+		required: []string{"sales_group_id", "product_id", "quantity", "sales_price"},
+		batch:    func(organizationID uuid.UUID) batch { return &saleBatch{organizationID: organizationID} },
+	},
+	generated.BulkLoadTableSalesGroup: {
+		name: generated.BulkLoadTableSalesGroup,
+		columns: []string{"sales_group_id", "total_amount", "total_profit", "payment_method", "sold_date", "branch_uuid",
+			"user_profile_id", "organization_id", "customer_name", "comments", "price_mode", "taxable_amount", "tax_amount",
+			"partner_id", "shift_id"},
+		required: []string{"total_amount", "sold_date", "branch_uuid", "user_profile_id"},
+		batch:    func(organizationID uuid.UUID) batch { return &salesGroupBatch{organizationID: organizationID} },
+	},
+}
+
+// header maps the columns of t to their position in a header row. Headers
+// match regardless of case and surrounding space.
+func (t table) header(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+		if i == 0 {
+			// Spreadsheet programs often start CSV exports with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if !slices.Contains(t.columns, name) {
+			return nil, fmt.Errorf("%q in %s: %w", h, t.name, ErrUnknownColumn)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%q: %w", h, ErrDuplicateColumn)
+		}
+		columns[name] = i
+	}
+	for _, name := range t.required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%q: %w", name, ErrMissingColumn)
+		}
+	}
+	return columns, nil
+}
+
+// record is one input row.
+type record struct {
+	line    int
+	columns map[string]int
+	fields  []string
+}
+
+func (r record) value(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// parser reads typed values from a record, keeping the first problem.
+type parser struct {
+	record
+	err *RowError
+}
+
+func (p *parser) fail(column string, err error) {
+	if p.err == nil {
+		p.err = &RowError{Line: p.line, Column: column, Value: p.value(column), Err: err}
+	}
+}
+
+func (p *parser) text(column string) string {
+	v := p.value(column)
+	if v == "" {
+		p.fail(column, ErrRequired)
+	}
+	return v
+}
+
+func (p *parser) nullText(column string) sql.NullString {
+	v := p.value(column)
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+// id reads a primary key, generating one when the value is empty.
+func (p *parser) id(column string) uuid.UUID {
+	if p.value(column) == "" {
+		id, err := uuid.NewV7()
+		if err != nil {
+			p.fail(column, err)
+		}
+		return id
+	}
+	return p.uuid(column)
+}
+
+func (p *parser) uuid(column string) uuid.UUID {
+	v := p.text(column)
+	if v == "" {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		p.fail(column, ErrInvalidUUID)
+	}
+	return id
+}
+
+func (p *parser) nullUUID(column string) uuid.NullUUID {
+	if p.value(column) == "" {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: p.uuid(column), Valid: true}
+}
+
+// organization checks that an organization_id, when given, is the load's.
+func (p *parser) organization(column string, organizationID uuid.UUID) uuid.UUID {
+	if p.value(column) != "" && p.uuid(column) != organizationID {
+		p.fail(column, ErrOtherOrganization)
+	}
+	return organizationID
+}
+
+func (p *parser) decimal(column string, valid func(decimal.Decimal) error) decimal.Decimal {
+	v := p.text(column)
+	if v == "" {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(v)
+	if err != nil {
+		p.fail(column, ErrInvalidDecimal)
+		return decimal.Zero
+	}
+	if valid != nil {
+		if err := valid(d); err != nil {
+			p.fail(column, err)
+		}
+	}
+	return d
+}
+
+// decimalOr reads an optional decimal, def when the value is empty.
+func (p *parser) decimalOr(column string, def decimal.Decimal, valid func(decimal.Decimal) error) decimal.Decimal {
+	if p.value(column) == "" {
+		return def
+	}
+	return p.decimal(column, valid)
+}
+
+// timeLayouts are the accepted timestamp formats: the exporter's, PostgreSQL
+// text output and plain dates. Values without a zone are taken as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+}
+
+// pastTime reads a required timestamp that is not in the future.
+func (p *parser) pastTime(column string) time.Time {
+	v := p.text(column)
+	if v == "" {
+		return time.Time{}
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			if t.After(time.Now()) {
+				p.fail(column, ErrFutureDate)
+			}
+			return t
+		}
+	}
+	p.fail(column, ErrInvalidTime)
+	return time.Time{}
+}
+
+func nonNegative(d decimal.Decimal) error {
+	if d.IsNegative() {
+		return ErrNegative
+	}
+	return nil
+}
+
+func positive(d decimal.Decimal) error {
+	if !d.IsPositive() {
+		return ErrNotPositive
+	}
+	return nil
+}
+
+func referenceError(line int, column string, id uuid.UUID, err error) RowError {
+	return RowError{Line: line, Column: column, Value: id.String(), Err: err}
+}
+
+// distinct returns the distinct valid ids of rows.
+func distinct[T any](rows []T, id func(T) uuid.NullUUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(rows))
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, r := range rows {
+		if v := id(r); v.Valid && !seen[v.UUID] {
+			seen[v.UUID] = true
+			ids = append(ids, v.UUID)
+		}
+	}
+	return ids
+}
+
+func set(ids []uuid.UUID) map[uuid.UUID]bool {
+	s := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		s[id] = true
+	}
+	return s
+}
+
+func valid(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
+
+// productUnit is a unit a product's quantity was entered in.
+type productUnit struct {
+	productID uuid.UUID
+	unit      string
+}
+
+// unitFactors returns how many of its product's measurement unit make one
+// of each unit; units that do not convert have no positive factor.
+func unitFactors(ctx context.Context, q *generated.Queries, entered []productUnit) (map[productUnit]decimal.Decimal, error) {
+	arg := generated.ListBulkLoadUnitFactorsParams{
+		ProductIds: make([]uuid.UUID, len(entered)),
+		Units:      make([]string, len(entered)),
+	}
+	for i, u := range entered {
+		arg.ProductIds[i], arg.Units[i] = u.productID, u.unit
+	}
+	rows, err := q.ListBulkLoadUnitFactors(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("list unit factors: %w", err)
+	}
+	factors := make(map[productUnit]decimal.Decimal, len(rows))
+	for _, r := range rows {
+		factors[productUnit{r.ProductID, r.Unit}] = r.Factor
+	}
+	return factors, nil
+}
+
+type purchaseLine struct {
+	line int
+	generated.Purchase
+}
+
+type purchaseBatch struct {
+	organizationID uuid.UUID
+	lines          []purchaseLine
+	seen           map[uuid.UUID]bool
+}
+
+func (b *purchaseBatch) add(r record) *RowError {
+	p := parser{record: r}
+	l := purchaseLine{line: r.line}
+	l.PurchaseID = p.id("purchase_id")
+	l.PurchaseGroupID = p.nullUUID("purchase_group_id")
+	l.ProductID = p.nullUUID("product_id")
+	l.ProductName = p.text("product_name")
+	l.UnitPurchasePrice = p.decimal("unit_purchase_price", nonNegative)
+	l.Units = p.decimal("units", positive)
+	l.BranchUuid = p.uuid("branch_uuid")
+	l.OrganizationID = p.organization("organization_id", b.organizationID)
+	// An empty purchased_unit is the product's measurement unit, filled in by check
+	l.PurchasedUnit = p.value("purchased_unit")
+	l.PurchasedQuantity = p.decimalOr("purchased_quantity", l.Units, positive)
+	if p.err == nil && l.PurchasedUnit == "" && !l.ProductID.Valid {
+		p.fail("purchased_unit", ErrRequired)
+	}
+	if p.err == nil && b.seen[l.PurchaseID] {
+		p.fail("purchase_id", ErrDuplicateID)
+	}
+	if p.err != nil {
+		return p.err
+	}
+	if b.seen == nil {
+		b.seen = make(map[uuid.UUID]bool)
+	}
+	b.seen[l.PurchaseID] = true
+	b.lines = append(b.lines, l)
+	return nil
+}
+
+func (b *purchaseBatch) check(ctx context.Context, q *generated.Queries) ([]RowError, error) {
+	existing, err := q.ListExistingPurchaseIDs(ctx, distinct(b.lines, func(l purchaseLine) uuid.NullUUID { return valid(l.PurchaseID) }))
+	if err != nil {
+		return nil, fmt.Errorf("list existing purchases: %w", err)
+	}
+	branches, err := q.ListBulkLoadBranches(ctx, generated.ListBulkLoadBranchesParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l purchaseLine) uuid.NullUUID { return valid(l.BranchUuid) }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	productRows, err := q.ListBulkLoadProducts(ctx, generated.ListBulkLoadProductsParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l purchaseLine) uuid.NullUUID { return l.ProductID }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list products: %w", err)
+	}
+	groupRows, err := q.ListBulkLoadPurchaseGroups(ctx, generated.ListBulkLoadPurchaseGroupsParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l purchaseLine) uuid.NullUUID { return l.PurchaseGroupID }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list purchase groups: %w", err)
+	}
+	taken, branchSet := set(existing), set(branches)
+	products := make(map[uuid.UUID]generated.ListBulkLoadProductsRow, len(productRows))
+	for _, p := range productRows {
+		products[p.ProductID] = p
+	}
+	groups := make(map[uuid.UUID]uuid.UUID, len(groupRows))
+	for _, g := range groupRows {
+		groups[g.PurchaseGroupID] = g.BranchUuid
+	}
+	var lineUnits []productUnit
+	for i, l := range b.lines {
+		if product, ok := products[l.ProductID.UUID]; ok && l.ProductID.Valid {
+			if l.PurchasedUnit == "" {
+				b.lines[i].PurchasedUnit = product.MeasurementUnit
+			}
+			lineUnits = append(lineUnits, productUnit{l.ProductID.UUID, b.lines[i].PurchasedUnit})
+		}
+	}
+	factors, err := unitFactors(ctx, q, lineUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []RowError
+	kept := b.lines[:0]
+	for _, l := range b.lines {
+		product, productFound := products[l.ProductID.UUID]
+		groupBranch, groupFound := groups[l.PurchaseGroupID.UUID]
+		factor := factors[productUnit{l.ProductID.UUID, l.PurchasedUnit}]
+		switch {
+		case taken[l.PurchaseID]:
+			errs = append(errs, referenceError(l.line, "purchase_id", l.PurchaseID, ErrDuplicateID))
+		case !branchSet[l.BranchUuid]:
+			errs = append(errs, referenceError(l.line, "branch_uuid", l.BranchUuid, ErrUnknownReference))
+		case l.ProductID.Valid && !productFound:
+			errs = append(errs, referenceError(l.line, "product_id", l.ProductID.UUID, ErrUnknownReference))
+		case l.ProductID.Valid && product.BranchUuid != l.BranchUuid:
+			errs = append(errs, referenceError(l.line, "product_id", l.ProductID.UUID, ErrBranchMismatch))
+		case l.PurchaseGroupID.Valid && !groupFound:
+			errs = append(errs, referenceError(l.line, "purchase_group_id", l.PurchaseGroupID.UUID, ErrUnknownReference))
+		case l.PurchaseGroupID.Valid && groupBranch != l.BranchUuid:
+			errs = append(errs, referenceError(l.line, "purchase_group_id", l.PurchaseGroupID.UUID, ErrBranchMismatch))
+		case l.ProductID.Valid && !factor.IsPositive():
+			errs = append(errs, RowError{Line: l.line, Column: "purchased_unit", Value: l.PurchasedUnit, Err: units.ErrNoConversion})
+		case l.ProductID.Valid && !l.Units.Equal(l.PurchasedQuantity.Mul(factor)):
+			errs = append(errs, RowError{Line: l.line, Column: "units", Value: l.Units.String(), Err: ErrQuantityMismatch})
+		default:
+			kept = append(kept, l)
+		}
+	}
+	b.lines = kept
+	return errs, nil
+}
+
+func (b *purchaseBatch) rows() [][]any {
+	rows := make([][]any, len(b.lines))
+	for i, l := range b.lines {
+		rows[i] = []any{l.PurchaseID, l.PurchaseGroupID, l.ProductID, l.ProductName, l.UnitPurchasePrice, l.Units,
+			l.BranchUuid, l.OrganizationID, l.PurchasedUnit, l.PurchasedQuantity}
+	}
+	return rows
+}
+
+type saleLine struct {
+	line int
+	generated.Sale
+}
+
+type saleBatch struct {
+	organizationID uuid.UUID
+	lines          []saleLine
+	seen           map[uuid.UUID]bool
+}
+
+// add parses a sale. Missing amounts default the way checkout computes
+// them: total is price times quantity, the taxable amount is the total less
+// tax and profit is the taxable amount less the cost of the quantity.
+func (b *saleBatch) add(r record) *RowError {
+	p := parser{record: r}
+	l := saleLine{line: r.line}
+	l.SalesID = p.id("sales_id")
+	l.SalesGroupID = valid(p.uuid("sales_group_id"))
+	l.ProductID = p.uuid("product_id")
+	l.Quantity = p.decimal("quantity", positive)
+	l.CurrentCostPrice = p.decimalOr("current_cost_price", decimal.Zero, nonNegative)
+	l.SalesPrice = p.decimal("sales_price", nonNegative)
+	l.Total = p.decimalOr("total", l.SalesPrice.Mul(l.Quantity), nonNegative)
+	l.TaxRate = p.decimalOr("tax_rate", decimal.Zero, nonNegative)
+	l.TaxAmount = p.decimalOr("tax_amount", decimal.Zero, nonNegative)
+	l.TaxableAmount = p.decimalOr("taxable_amount", l.Total.Sub(l.TaxAmount), nonNegative)
+	l.Profit = p.decimalOr("profit", l.TaxableAmount.Sub(l.CurrentCostPrice.Mul(l.Quantity)), nil)
+	// An empty sold_unit is the product's measurement unit, filled in by check
+	l.SoldUnit = p.value("sold_unit")
+	l.SoldQuantity = p.decimalOr("sold_quantity", l.Quantity, positive)
+	if p.err == nil && b.seen[l.SalesID] {
+		p.fail("sales_id", ErrDuplicateID)
+	}
+	if p.err != nil {
+		return p.err
+	}
+	if b.seen == nil {
+		b.seen = make(map[uuid.UUID]bool)
+	}
+	b.seen[l.SalesID] = true
+	b.lines = append(b.lines, l)
+	return nil
+}
+
+func (b *saleBatch) check(ctx context.Context, q *generated.Queries) ([]RowError, error) {
+	existing, err := q.ListExistingSaleIDs(ctx, distinct(b.lines, func(l saleLine) uuid.NullUUID { return valid(l.SalesID) }))
+	if err != nil {
+		return nil, fmt.Errorf("list existing sales: %w", err)
+	}
+	groupRows, err := q.ListBulkLoadSalesGroups(ctx, distinct(b.lines, func(l saleLine) uuid.NullUUID { return l.SalesGroupID }))
+	if err != nil {
+		return nil, fmt.Errorf("list sales groups: %w", err)
+	}
+	productRows, err := q.ListBulkLoadProducts(ctx, generated.ListBulkLoadProductsParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l saleLine) uuid.NullUUID { return valid(l.ProductID) }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list products: %w", err)
+	}
+	taken := set(existing)
+	groups := make(map[uuid.UUID]uuid.UUID, len(groupRows))
+	for _, g := range groupRows {
+		// Sales have no organization of their own; their group's decides
+		if g.OrganizationID == b.organizationID {
+			groups[g.SalesGroupID] = g.BranchUuid
+		}
+	}
+	products := make(map[uuid.UUID]generated.ListBulkLoadProductsRow, len(productRows))
+	for _, p := range productRows {
+		products[p.ProductID] = p
+	}
+	var lineUnits []productUnit
+	for i, l := range b.lines {
+		if product, ok := products[l.ProductID]; ok {
+			if l.SoldUnit == "" {
+				b.lines[i].SoldUnit = product.MeasurementUnit
+			}
+			lineUnits = append(lineUnits, productUnit{l.ProductID, b.lines[i].SoldUnit})
+		}
+	}
+	factors, err := unitFactors(ctx, q, lineUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []RowError
+	kept := b.lines[:0]
+	for _, l := range b.lines {
+		groupBranch, groupFound := groups[l.SalesGroupID.UUID]
+		product, productFound := products[l.ProductID]
+		factor := factors[productUnit{l.ProductID, l.SoldUnit}]
+		switch {
+		case taken[l.SalesID]:
+			errs = append(errs, referenceError(l.line, "sales_id", l.SalesID, ErrDuplicateID))
+		case !groupFound:
+			errs = append(errs, referenceError(l.line, "sales_group_id", l.SalesGroupID.UUID, ErrUnknownReference))
+		case !productFound:
+			errs = append(errs, referenceError(l.line, "product_id", l.ProductID, ErrUnknownReference))
+		case product.BranchUuid != groupBranch:
+			errs = append(errs, referenceError(l.line, "product_id", l.ProductID, ErrBranchMismatch))
+		case !factor.IsPositive():
+			errs = append(errs, RowError{Line: l.line, Column: "sold_unit", Value: l.SoldUnit, Err: units.ErrNoConversion})
+		case !l.Quantity.Equal(l.SoldQuantity.Mul(factor)):
+			errs = append(errs, RowError{Line: l.line, Column: "quantity", Value: l.Quantity.String(), Err: ErrQuantityMismatch})
+		default:
+			kept = append(kept, l)
+		}
+	}
+	b.lines = kept
+	return errs, nil
+}
+
+func (b *saleBatch) rows() [][]any {
+	rows := make([][]any, len(b.lines))
+	for i, l := range b.lines {
+		rows[i] = []any{l.SalesID, l.SalesGroupID, l.ProductID, l.Quantity, l.CurrentCostPrice, l.SalesPrice, l.Total,
+			l.Profit, l.TaxRate, l.TaxableAmount, l.TaxAmount, l.SoldUnit, l.SoldQuantity}
+	}
+	return rows
+}
+
+type salesGroupLine struct {
+	line int
+	generated.SalesGroup
+	// methodID is the payment method settling the group with a tender, set
+	// by check. It stays unset for credit methods, whose tenders would post
+	// to the customer's balance.
+	methodID uuid.NullUUID
+}
+
+type salesGroupBatch struct {
+	organizationID uuid.UUID
+	lines          []salesGroupLine
+	seen           map[uuid.UUID]bool
+}
+
+// add parses a sales group. The taxable amount defaults to the total less
+// tax and an empty price_mode to the organization's, filled in by check.
+func (b *salesGroupBatch) add(r record) *RowError {
+	p := parser{record: r}
+	l := salesGroupLine{line: r.line}
+	l.SalesGroupID = p.id("sales_group_id")
+	l.TotalAmount = p.decimal("total_amount", nonNegative)
+	l.TotalProfit = p.decimalOr("total_profit", decimal.Zero, nil)
+	l.PaymentMethod = p.nullText("payment_method")
+	l.SoldDate = p.pastTime("sold_date")
+	l.BranchUuid = p.uuid("branch_uuid")
+	l.UserProfileID = p.uuid("user_profile_id")
+	l.OrganizationID = p.organization("organization_id", b.organizationID)
+	l.CustomerName = p.nullText("customer_name")
+	l.Comments = p.nullText("comments")
+	l.PriceMode = generated.PriceMode(p.value("price_mode"))
+	switch l.PriceMode {
+	case "", generated.PriceModeTaxExclusive, generated.PriceModeTaxInclusive:
+	default:
+		p.fail("price_mode", ErrInvalidValue)
+	}
+	l.TaxAmount = p.decimalOr("tax_amount", decimal.Zero, nonNegative)
+	l.TaxableAmount = p.decimalOr("taxable_amount", l.TotalAmount.Sub(l.TaxAmount), nonNegative)
+	l.PartnerID = p.nullUUID("partner_id")
+	l.ShiftID = p.nullUUID("shift_id")
+	if p.err == nil && b.seen[l.SalesGroupID] {
+		p.fail("sales_group_id", ErrDuplicateID)
+	}
+	if p.err != nil {
+		return p.err
+	}
+	if b.seen == nil {
+		b.seen = make(map[uuid.UUID]bool)
+	}
+	b.seen[l.SalesGroupID] = true
+	b.lines = append(b.lines, l)
+	return nil
+}
+
+func (b *salesGroupBatch) check(ctx context.Context, q *generated.Queries) ([]RowError, error) {
+	priceMode, err := q.GetOrganizationPriceMode(ctx, b.organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("get price mode: %w", err)
+	}
+	existing, err := q.ListBulkLoadSalesGroups(ctx, distinct(b.lines, func(l salesGroupLine) uuid.NullUUID { return valid(l.SalesGroupID) }))
+	if err != nil {
+		return nil, fmt.Errorf("list existing sales groups: %w", err)
+	}
+	branches, err := q.ListBulkLoadBranches(ctx, generated.ListBulkLoadBranchesParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l salesGroupLine) uuid.NullUUID { return valid(l.BranchUuid) }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list branches: %w", err)
+	}
+	members, err := q.ListBulkLoadMembers(ctx, generated.ListBulkLoadMembersParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l salesGroupLine) uuid.NullUUID { return valid(l.UserProfileID) }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list members: %w", err)
+	}
+	customers, err := q.ListBulkLoadCustomers(ctx, generated.ListBulkLoadCustomersParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l salesGroupLine) uuid.NullUUID { return l.PartnerID }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list customers: %w", err)
+	}
+	var methodNames []string
+	for _, l := range b.lines {
+		if l.PaymentMethod.Valid && !slices.Contains(methodNames, l.PaymentMethod.String) {
+			methodNames = append(methodNames, l.PaymentMethod.String)
+		}
+	}
+	methodRows, err := q.ListBulkLoadPaymentMethods(ctx, generated.ListBulkLoadPaymentMethodsParams{
+		OrganizationID: b.organizationID,
+		Names:          methodNames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list payment methods: %w", err)
+	}
+	shiftRows, err := q.ListBulkLoadShifts(ctx, generated.ListBulkLoadShiftsParams{
+		OrganizationID: b.organizationID,
+		Ids:            distinct(b.lines, func(l salesGroupLine) uuid.NullUUID { return l.ShiftID }),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list shifts: %w", err)
+	}
+	taken, branchSet, memberSet, customerSet := make(map[uuid.UUID]bool, len(existing)), set(branches), set(members), set(customers)
+	for _, g := range existing {
+		taken[g.SalesGroupID] = true
+	}
+	shifts := make(map[uuid.UUID]uuid.UUID, len(shiftRows))
+	for _, s := range shiftRows {
+		shifts[s.ShiftID] = s.BranchUuid
+	}
+	methods := make(map[string]generated.ListBulkLoadPaymentMethodsRow, len(methodRows))
+	for _, m := range methodRows {
+		methods[m.Name] = m
+	}
+
+	var errs []RowError
+	kept := b.lines[:0]
+	for _, l := range b.lines {
+		shiftBranch, shiftFound := shifts[l.ShiftID.UUID]
+		method, methodFound := methods[l.PaymentMethod.String]
+		// Credit sales are owed by a customer
+		credit := l.PaymentMethod.String == partners.CreditPaymentMethod ||
+			methodFound && method.Kind == generated.PaymentMethodKindCredit
+		switch {
+		case taken[l.SalesGroupID]:
+			errs = append(errs, referenceError(l.line, "sales_group_id", l.SalesGroupID, ErrDuplicateID))
+		case !branchSet[l.BranchUuid]:
+			errs = append(errs, referenceError(l.line, "branch_uuid", l.BranchUuid, ErrUnknownReference))
+		case !memberSet[l.UserProfileID]:
+			errs = append(errs, referenceError(l.line, "user_profile_id", l.UserProfileID, ErrUnknownReference))
+		case l.PartnerID.Valid && !customerSet[l.PartnerID.UUID]:
+			errs = append(errs, referenceError(l.line, "partner_id", l.PartnerID.UUID, ErrUnknownReference))
+		case l.PaymentMethod.Valid && !methodFound:
+			errs = append(errs, RowError{Line: l.line, Column: "payment_method", Value: l.PaymentMethod.String, Err: ErrUnknownReference})
+		case credit && !l.PartnerID.Valid:
+			errs = append(errs, RowError{Line: l.line, Column: "partner_id", Err: ErrRequired})
+		case l.ShiftID.Valid && !shiftFound:
+			errs = append(errs, referenceError(l.line, "shift_id", l.ShiftID.UUID, ErrUnknownReference))
+		case l.ShiftID.Valid && shiftBranch != l.BranchUuid:
+			errs = append(errs, referenceError(l.line, "shift_id", l.ShiftID.UUID, ErrBranchMismatch))
+		default:
+			if l.PriceMode == "" {
+				l.PriceMode = priceMode
+			}
+			l.methodID = uuid.NullUUID{UUID: method.MethodID, Valid: methodFound && !credit}
+			kept = append(kept, l)
+		}
+	}
+	b.lines = kept
+	return errs, nil
+}
+
+func (b *salesGroupBatch) rows() [][]any {
+	rows := make([][]any, len(b.lines))
+	for i, l := range b.lines {
+		rows[i] = []any{l.SalesGroupID, l.TotalAmount, l.TotalProfit, l.PaymentMethod, l.SoldDate, l.BranchUuid,
+			l.UserProfileID, l.OrganizationID, l.CustomerName, l.Comments, l.PriceMode, l.TaxableAmount, l.TaxAmount,
+			l.PartnerID, l.ShiftID}
+	}
+	return rows
+}
+
+// dependentRows settles each kept group that has a payment method other
+// than credit with one tender of its full amount.
+func (b *salesGroupBatch) dependentRows() (string, []string, [][]any) {
+	var rows [][]any
+	for _, l := range b.lines {
+		if l.methodID.Valid {
+			rows = append(rows, []any{l.SalesGroupID, l.methodID.UUID, l.TotalAmount, l.TotalAmount})
+		}
+	}
+	return "sales_tenders", []string{"sales_group_id", "method_id", "amount", "tendered"}, rows
+}
@@ -0,0 +1,283 @@
+// Package catalog groups products into variants and resolves the barcodes
+// and SKUs scanned at the till.
+//
+// Products are identified across branches by unique_name, and so are
+// variants and codes: a variant is a product of its own, with its own stock
+// and price in every branch, listed under the unique_name of its parent. A
+// code is unique within the organization and resolves to the product of its
+// unique_name in the branch where it is scanned.
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+var (
+	// ErrProductNotFound is returned when no branch of the organization stocks a product.
+	ErrProductNotFound = errors.New("catalog: product not found")
+	// ErrNotStocked is returned when a code resolves to a product the branch does not stock.
+	ErrNotStocked = errors.New("catalog: product not stocked in branch")
+	// ErrVariantNotFound is returned when a product is not a variant.
+	ErrVariantNotFound = errors.New("catalog: variant not found")
+	// ErrNestedVariant is returned when a variant would be the parent of another variant.
+	ErrNestedVariant = errors.New("catalog: variants cannot have variants")
+	// ErrCodeNotFound is returned when a barcode or SKU is not known to the organization.
+	ErrCodeNotFound = errors.New("catalog: code not found")
+	// ErrDuplicateCode is returned when a barcode or SKU already belongs to a product.
+	ErrDuplicateCode = errors.New("catalog: code already in use")
+	// ErrInvalidCode is returned when a code is empty or of an unknown type.
+	ErrInvalidCode = errors.New("catalog: code must be a non-empty barcode or sku")
+)
+
+// managerRoles may change the catalog for products stocked in their branches.
+var managerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// Service manages product variants and codes.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a catalog Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// SetVariant lists uniqueName as a variant of parentUniqueName, described by
+// options such as {"size": "M", "colour": "red"}. Setting it again moves the
+// variant or replaces its options. A variant cannot have variants itself.
+func (s *Service) SetVariant(ctx context.Context, organizationID, userProfileID uuid.UUID, uniqueName, parentUniqueName string, options map[string]string) (generated.ProductVariant, error) {
+	if options == nil {
+		options = map[string]string{}
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return generated.ProductVariant{}, fmt.Errorf("encode variant options: %w", err)
+	}
+	if uniqueName == parentUniqueName {
+		return generated.ProductVariant{}, fmt.Errorf("%s: %w", uniqueName, ErrNestedVariant)
+	}
+	var variant generated.ProductVariant
+	err = s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{uniqueName, parentUniqueName} {
+			if err := authorize(ctx, q, actor, name); err != nil {
+				return err
+			}
+		}
+		parent, err := standing(ctx, q, organizationID, parentUniqueName)
+		if err != nil {
+			return err
+		}
+		child, err := standing(ctx, q, organizationID, uniqueName)
+		if err != nil {
+			return err
+		}
+		if parent.IsVariant || child.HasVariants {
+			return fmt.Errorf("%s under %s: %w", uniqueName, parentUniqueName, ErrNestedVariant)
+		}
+		variant, err = q.UpsertProductVariant(ctx, generated.UpsertProductVariantParams{
+			OrganizationID:   organizationID,
+			UniqueName:       uniqueName,
+			ParentUniqueName: parentUniqueName,
+			Options:          encoded,
+		})
+		if err != nil {
+			return fmt.Errorf("upsert product variant: %w", err)
+		}
+		return nil
+	})
+	return variant, err
+}
+
+// RemoveVariant makes a variant a product of its own again.
+func (s *Service) RemoveVariant(ctx context.Context, organizationID, userProfileID uuid.UUID, uniqueName string) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, q, actor, uniqueName); err != nil {
+			return err
+		}
+		n, err := q.DeleteProductVariant(ctx, generated.DeleteProductVariantParams{
+			OrganizationID: organizationID,
+			UniqueName:     uniqueName,
+		})
+		if err != nil {
+			return fmt.Errorf("delete product variant: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("%s: %w", uniqueName, ErrVariantNotFound)
+		}
+		return nil
+	})
+}
+
+// Variants returns the variants of a parent product.
+func (s *Service) Variants(ctx context.Context, organizationID uuid.UUID, parentUniqueName string) ([]generated.ProductVariant, error) {
+	return s.db.Queries().ListProductVariants(ctx, generated.ListProductVariantsParams{
+		OrganizationID:   organizationID,
+		ParentUniqueName: parentUniqueName,
+	})
+}
+
+// AddCode gives a product or variant a barcode or SKU. A product may have
+// any number of codes, but a code belongs to one product of the
+// organization.
+func (s *Service) AddCode(ctx context.Context, organizationID, userProfileID uuid.UUID, uniqueName, code string, codeType generated.ProductCodeType) (generated.ProductCode, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || !validCodeType(codeType) {
+		return generated.ProductCode{}, ErrInvalidCode
+	}
+	var added generated.ProductCode
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, q, actor, uniqueName); err != nil {
+			return err
+		}
+		added, err = q.InsertProductCode(ctx, generated.InsertProductCodeParams{
+			OrganizationID: organizationID,
+			Code:           code,
+			CodeType:       codeType,
+			UniqueName:     uniqueName,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%q: %w", code, ErrDuplicateCode)
+		}
+		if err != nil {
+			return fmt.Errorf("insert product code: %w", err)
+		}
+		return nil
+	})
+	return added, err
+}
+
+// RemoveCode removes a barcode or SKU from the product it belongs to.
+func (s *Service) RemoveCode(ctx context.Context, organizationID, userProfileID uuid.UUID, code string) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		existing, err := q.GetProductCode(ctx, generated.GetProductCodeParams{
+			OrganizationID: organizationID,
+			Code:           code,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%q: %w", code, ErrCodeNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("get product code: %w", err)
+		}
+		if err := authorize(ctx, q, actor, existing.UniqueName); err != nil {
+			return err
+		}
+		_, err = q.DeleteProductCode(ctx, generated.DeleteProductCodeParams{
+			OrganizationID: organizationID,
+			Code:           code,
+		})
+		if err != nil {
+			return fmt.Errorf("delete product code: %w", err)
+		}
+		return nil
+	})
+}
+
+// Codes returns the barcodes and SKUs of a product or variant.
+func (s *Service) Codes(ctx context.Context, organizationID uuid.UUID, uniqueName string) ([]generated.ProductCode, error) {
+	return s.db.Queries().ListProductCodes(ctx, generated.ListProductCodesParams{
+		OrganizationID: organizationID,
+		UniqueName:     uniqueName,
+	})
+}
+
+// Lookup resolves a scanned barcode or SKU to the product a branch sells
+// under it, ready to be put on a sales.LineItem.
+func (s *Service) Lookup(ctx context.Context, organizationID, branchUuid uuid.UUID, code string) (generated.LookupBranchProductByCodeRow, error) {
+	return Lookup(ctx, s.db.Queries(), organizationID, branchUuid, code)
+}
+
+// Lookup resolves a barcode or SKU with q, for callers that already hold a
+// transaction.
+func Lookup(ctx context.Context, q *generated.Queries, organizationID, branchUuid uuid.UUID, code string) (generated.LookupBranchProductByCodeRow, error) {
+	code = strings.TrimSpace(code)
+	product, err := q.LookupBranchProductByCode(ctx, generated.LookupBranchProductByCodeParams{
+		BranchUuid:     branchUuid,
+		OrganizationID: organizationID,
+		Code:           code,
+	})
+	if err == nil {
+		return product, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return product, fmt.Errorf("lookup product code: %w", err)
+	}
+	// Tell an unknown code from a product the branch does not stock
+	existing, err := q.GetProductCode(ctx, generated.GetProductCodeParams{
+		OrganizationID: organizationID,
+		Code:           code,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, fmt.Errorf("%q: %w", code, ErrCodeNotFound)
+	}
+	if err != nil {
+		return product, fmt.Errorf("get product code: %w", err)
+	}
+	return product, fmt.Errorf("%s: %w", existing.UniqueName, ErrNotStocked)
+}
+
+// authorize checks the actor manages a branch that stocks uniqueName.
+func authorize(ctx context.Context, q *generated.Queries, actor access.Actor, uniqueName string) error {
+	branches, err := q.ListProductBranches(ctx, generated.ListProductBranchesParams{
+		OrganizationID: actor.OrganizationID,
+		UniqueName:     uniqueName,
+	})
+	if err != nil {
+		return fmt.Errorf("list product branches: %w", err)
+	}
+	if len(branches) == 0 {
+		return fmt.Errorf("%s: %w", uniqueName, ErrProductNotFound)
+	}
+	for _, branch := range branches {
+		if err = actor.RequireBranchRole(branch, managerRoles...); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// standing reports whether uniqueName is a variant or has variants.
+func standing(ctx context.Context, q *generated.Queries, organizationID uuid.UUID, uniqueName string) (generated.GetVariantStandingRow, error) {
+	row, err := q.GetVariantStanding(ctx, generated.GetVariantStandingParams{
+		OrganizationID: organizationID,
+		UniqueName:     uniqueName,
+	})
+	if err != nil {
+		return row, fmt.Errorf("get variant standing: %w", err)
+	}
+	return row, nil
+}
+
+func validCodeType(t generated.ProductCodeType) bool {
+	switch t {
+	case generated.ProductCodeTypeBarcode, generated.ProductCodeTypeSku:
+		return true
+	}
+	return false
+}
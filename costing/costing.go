@@ -0,0 +1,256 @@
+// Package costing values stock and derives the cost of goods sold.
+//
+// Every receipt of stock adds a cost layer and updates the product's moving
+// weighted average cost, and every issue consumes layers oldest first, so
+// both methods stay available whichever one an organization uses. The
+// organization's costing_method decides whether an issue is charged at the
+// average cost or at the cost of the layers it consumed.
+package costing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// unitCostPlaces is the precision unit costs are rounded to.
+const unitCostPlaces = 6
+
+// ErrUnknownOrganization is returned when the organization does not exist.
+var ErrUnknownOrganization = errors.New("costing: organization not found")
+
+// Receipt is stock entering a branch at a known unit cost.
+type Receipt struct {
+	ProductID      uuid.UUID
+	BranchUuid     uuid.UUID
+	OrganizationID uuid.UUID
+	ReferenceID    uuid.NullUUID
+	Quantity       decimal.Decimal
+	UnitCost       decimal.Decimal
+}
+
+// Issue is stock leaving a product, charged under Method. ReferenceID ties
+// the consumed layers to the sale line, transfer line or adjustment.
+type Issue struct {
+	ProductID   uuid.UUID
+	ReferenceID uuid.UUID
+	Quantity    decimal.Decimal
+	Method      generated.CostingMethod
+}
+
+// Cost is the cost charged for an Issue.
+type Cost struct {
+	UnitCost decimal.Decimal
+	Total    decimal.Decimal
+}
+
+// MethodFor returns the costing method configured for an organization.
+func MethodFor(ctx context.Context, q *generated.Queries, organizationID uuid.UUID) (generated.CostingMethod, error) {
+	method, err := q.GetOrganizationCostingMethod(ctx, organizationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUnknownOrganization
+	}
+	if err != nil {
+		return "", fmt.Errorf("get costing method: %w", err)
+	}
+	return method, nil
+}
+
+// Receive adds a cost layer for r and folds it into the product's average
+// cost. It must run after the receipt's stock movement has been recorded, so
+// that remaining_quantity already includes r.Quantity.
+func Receive(ctx context.Context, q *generated.Queries, r Receipt) error {
+	if !r.Quantity.IsPositive() {
+		return nil
+	}
+	_, err := q.InsertCostLayer(ctx, generated.InsertCostLayerParams{
+		ProductID:      r.ProductID,
+		BranchUuid:     r.BranchUuid,
+		OrganizationID: r.OrganizationID,
+		ReferenceID:    r.ReferenceID,
+		UnitCost:       r.UnitCost,
+		Quantity:       r.Quantity,
+	})
+	if err != nil {
+		return fmt.Errorf("insert cost layer for product %s: %w", r.ProductID, err)
+	}
+	_, err = q.ApplyReceiptToAverageCost(ctx, generated.ApplyReceiptToAverageCostParams{
+		Quantity:  r.Quantity,
+		UnitCost:  r.UnitCost,
+		ProductID: r.ProductID,
+	})
+	if err != nil {
+		return fmt.Errorf("update average cost for product %s: %w", r.ProductID, err)
+	}
+	return nil
+}
+
+// IssueStock consumes cost layers for i and returns the cost to charge. Any
+// quantity not covered by layers, such as stock sold before it was
+// purchased, is charged at the average cost.
+func IssueStock(ctx context.Context, q *generated.Queries, i Issue) (Cost, error) {
+	if !i.Quantity.IsPositive() {
+		return Cost{}, nil
+	}
+	product, err := q.LockProductCost(ctx, i.ProductID)
+	if err != nil {
+		return Cost{}, fmt.Errorf("lock product cost %s: %w", i.ProductID, err)
+	}
+	layers, err := q.LockOpenCostLayers(ctx, i.ProductID)
+	if err != nil {
+		return Cost{}, fmt.Errorf("lock cost layers for product %s: %w", i.ProductID, err)
+	}
+
+	fifoTotal := decimal.Zero
+	need := i.Quantity
+	for _, layer := range layers {
+		if !need.IsPositive() {
+			break
+		}
+		take := decimal.Min(need, layer.RemainingQuantity)
+		err := q.ConsumeCostLayer(ctx, generated.ConsumeCostLayerParams{
+			Quantity: take,
+			LayerID:  layer.LayerID,
+		})
+		if err != nil {
+			return Cost{}, fmt.Errorf("consume cost layer %s: %w", layer.LayerID, err)
+		}
+		err = q.InsertCostAllocation(ctx, generated.InsertCostAllocationParams{
+			LayerID:     layer.LayerID,
+			ReferenceID: i.ReferenceID,
+			Quantity:    take,
+			UnitCost:    layer.UnitCost,
+		})
+		if err != nil {
+			return Cost{}, fmt.Errorf("insert cost allocation: %w", err)
+		}
+		fifoTotal = fifoTotal.Add(take.Mul(layer.UnitCost))
+		need = need.Sub(take)
+	}
+	fifoTotal = fifoTotal.Add(need.Mul(product.AverageCost))
+
+	total := i.Quantity.Mul(product.AverageCost)
+	if i.Method == generated.CostingMethodFifo {
+		total = fifoTotal
+	}
+	return Cost{
+		UnitCost: total.DivRound(i.Quantity, unitCostPlaces),
+		Total:    total,
+	}, nil
+}
+
+// Engine reads and changes the costing configuration and values stock.
+type Engine struct {
+	db *store.DB
+}
+
+// NewEngine returns an Engine backed by db.
+func NewEngine(db *store.DB) *Engine {
+	return &Engine{db: db}
+}
+
+// Method returns the costing method of an organization.
+func (e *Engine) Method(ctx context.Context, organizationID uuid.UUID) (generated.CostingMethod, error) {
+	return MethodFor(ctx, e.db.Queries(), organizationID)
+}
+
+// SetMethod changes the costing method of an organization. Sales recorded
+// afterwards are costed with the new method; earlier sales keep their cost.
+func (e *Engine) SetMethod(ctx context.Context, organizationID uuid.UUID, method generated.CostingMethod) error {
+	switch method {
+	case generated.CostingMethodWeightedAverage, generated.CostingMethodFifo:
+	default:
+		return fmt.Errorf("costing: unknown costing method %q", method)
+	}
+	return e.db.WithTx(ctx, func(q *generated.Queries) error {
+		return q.SetOrganizationCostingMethod(ctx, generated.SetOrganizationCostingMethodParams{
+			ID:            organizationID,
+			CostingMethod: method,
+		})
+	})
+}
+
+// BranchValuation is the value of the stock held by a branch.
+type BranchValuation struct {
+	BranchUuid   uuid.UUID       `json:"branch_uuid"`
+	BranchName   string          `json:"branch_name"`
+	ProductCount int64           `json:"product_count"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	Value        decimal.Decimal `json:"value"`
+}
+
+// ProductValuation is the value of the stock of one product.
+type ProductValuation struct {
+	ProductID   uuid.UUID       `json:"product_id"`
+	UniqueName  string          `json:"unique_name"`
+	ProductName string          `json:"product_name"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	Value       decimal.Decimal `json:"value"`
+}
+
+// BranchValuations values the stock of every branch of an organization with
+// the organization's costing method.
+func (e *Engine) BranchValuations(ctx context.Context, organizationID uuid.UUID) (generated.CostingMethod, []BranchValuation, error) {
+	q := e.db.Queries()
+	method, err := MethodFor(ctx, q, organizationID)
+	if err != nil {
+		return "", nil, err
+	}
+	rows, err := q.ListBranchInventoryValuations(ctx, organizationID)
+	if err != nil {
+		return "", nil, fmt.Errorf("list branch valuations: %w", err)
+	}
+	valuations := make([]BranchValuation, 0, len(rows))
+	for _, r := range rows {
+		value := r.AverageCostValue
+		if method == generated.CostingMethodFifo {
+			value = r.FifoValue
+		}
+		valuations = append(valuations, BranchValuation{
+			BranchUuid:   r.BranchUuid,
+			BranchName:   r.BranchName,
+			ProductCount: r.ProductCount,
+			Quantity:     r.TotalQuantity,
+			Value:        value,
+		})
+	}
+	return method, valuations, nil
+}
+
+// ProductValuations values the stock of every product in a branch with the
+// organization's costing method.
+func (e *Engine) ProductValuations(ctx context.Context, organizationID, branchUuid uuid.UUID) (generated.CostingMethod, []ProductValuation, error) {
+	q := e.db.Queries()
+	method, err := MethodFor(ctx, q, organizationID)
+	if err != nil {
+		return "", nil, err
+	}
+	rows, err := q.ListProductValuations(ctx, generated.ListProductValuationsParams{
+		OrganizationID: organizationID,
+		BranchUuid:     branchUuid,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("list product valuations: %w", err)
+	}
+	valuations := make([]ProductValuation, 0, len(rows))
+	for _, r := range rows {
+		value := r.AverageCostValue
+		if method == generated.CostingMethodFifo {
+			value = r.FifoValue
+		}
+		valuations = append(valuations, ProductValuation{
+			ProductID:   r.ProductID,
+			UniqueName:  r.UniqueName,
+			ProductName: r.ProductName,
+			Quantity:    r.RemainingQuantity,
+			Value:       value,
+		})
+	}
+	return method, valuations, nil
+}
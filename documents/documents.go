@@ -0,0 +1,166 @@
+// Package documents issues numbered invoices and receipts for sales and
+// renders them as PDF or as plain text for thermal printers.
+//
+// Numbers are sequential per branch and document type. A sale has at most
+// one document of each type; issuing it again returns the same number.
+package documents
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/sales"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// ErrUnknownDocumentType is returned for document types other than invoice and receipt.
+var ErrUnknownDocumentType = errors.New("documents: unknown document type")
+
+// issuerRoles may issue documents for the sales of their branches.
+var issuerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager, access.RoleSales}
+
+// numberPrefixes start the printed number of each document type.
+var numberPrefixes = map[generated.DocumentType]string{
+	generated.DocumentTypeInvoice: "INV",
+	generated.DocumentTypeReceipt: "RCT",
+}
+
+// Document is an issued invoice or receipt with everything needed to print
+// it.
+type Document struct {
+	generated.SalesDocument
+	Number  string                             `json:"number"`
+	Header  generated.GetDocumentHeaderRow     `json:"header"`
+	Sale    generated.SalesGroup               `json:"sale"`
+	Lines   []generated.ListSalesGroupLinesRow `json:"lines"`
+	Tenders []generated.ListSalesTendersRow    `json:"tenders"`
+}
+
+// Title is the heading printed on the document.
+func (d Document) Title() string {
+	if d.DocumentType == generated.DocumentTypeReceipt {
+		return "RECEIPT"
+	}
+	return "TAX INVOICE"
+}
+
+// FormatNumber returns the printed number of a document, such as
+// INV-main-000042.
+func FormatNumber(docType generated.DocumentType, branchUniqueName string, number int64) string {
+	return fmt.Sprintf("%s-%s-%06d", numberPrefixes[docType], branchUniqueName, number)
+}
+
+// Service issues sales documents.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a documents Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Issue returns the document of docType for a sale, numbering it on first
+// issue. When two calls issue the same document at once, the one that loses
+// the race returns the document saved by the other.
+func (s *Service) Issue(ctx context.Context, salesGroupID, organizationID, userProfileID uuid.UUID, docType generated.DocumentType) (Document, error) {
+	if _, ok := numberPrefixes[docType]; !ok {
+		return Document{}, fmt.Errorf("%q: %w", docType, ErrUnknownDocumentType)
+	}
+	doc, err := s.issueDocument(ctx, salesGroupID, organizationID, userProfileID, docType)
+	if store.IsUniqueViolation(err) {
+		// The losing transaction rolled back, number included; the retry
+		// finds the saved document.
+		doc, err = s.issueDocument(ctx, salesGroupID, organizationID, userProfileID, docType)
+	}
+	return doc, err
+}
+
+func (s *Service) issueDocument(ctx context.Context, salesGroupID, organizationID, userProfileID uuid.UUID, docType generated.DocumentType) (Document, error) {
+	var doc Document
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		doc.Sale, err = q.GetSalesGroup(ctx, generated.GetSalesGroupParams{
+			SalesGroupID:   salesGroupID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return sales.ErrSaleNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get sales group: %w", err)
+		}
+		if err := actor.RequireBranchRole(doc.Sale.BranchUuid, issuerRoles...); err != nil {
+			return err
+		}
+
+		doc.SalesDocument, err = q.GetSalesDocument(ctx, generated.GetSalesDocumentParams{
+			SalesGroupID: salesGroupID,
+			DocumentType: docType,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			doc.SalesDocument, err = issue(ctx, q, doc.Sale, docType, userProfileID)
+			if err != nil {
+				return fmt.Errorf("issue sales document: %w", err)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("get sales document: %w", err)
+		}
+
+		doc.Header, err = q.GetDocumentHeader(ctx, generated.GetDocumentHeaderParams{
+			ID:             doc.Sale.BranchUuid,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return fmt.Errorf("get document header: %w", err)
+		}
+		doc.Lines, err = q.ListSalesGroupLines(ctx, uuid.NullUUID{UUID: salesGroupID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("list sales lines: %w", err)
+		}
+		doc.Tenders, err = q.ListSalesTenders(ctx, salesGroupID)
+		if err != nil {
+			return fmt.Errorf("list sales tenders: %w", err)
+		}
+		doc.Number = FormatNumber(docType, doc.Header.BranchUniqueName, doc.DocumentNumber)
+		return nil
+	})
+	return doc, err
+}
+
+// issue takes the next number of the branch and saves the document.
+func issue(ctx context.Context, q *generated.Queries, sale generated.SalesGroup, docType generated.DocumentType, userProfileID uuid.UUID) (generated.SalesDocument, error) {
+	number, err := q.NextDocumentNumber(ctx, generated.NextDocumentNumberParams{
+		BranchUuid:   sale.BranchUuid,
+		DocumentType: docType,
+	})
+	if err != nil {
+		return generated.SalesDocument{}, fmt.Errorf("next document number: %w", err)
+	}
+	return q.InsertSalesDocument(ctx, generated.InsertSalesDocumentParams{
+		SalesGroupID:   sale.SalesGroupID,
+		BranchUuid:     sale.BranchUuid,
+		OrganizationID: sale.OrganizationID,
+		DocumentType:   docType,
+		DocumentNumber: number,
+		IssuedBy:       userProfileID,
+	})
+}
+
+// soldPrice returns the price of one unit of a line in the unit it was sold in.
+func soldPrice(l generated.ListSalesGroupLinesRow) decimal.Decimal {
+	if l.SoldQuantity.IsZero() {
+		return l.SalesPrice
+	}
+	return l.SalesPrice.Mul(l.Quantity).Div(l.SoldQuantity)
+}
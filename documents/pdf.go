@@ -0,0 +1,106 @@
+package documents
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// WritePDF renders doc as an A4 PDF.
+func WritePDF(w io.Writer, doc Document) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetTitle(doc.Title()+" "+doc.Number, true)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	contentWidth := pageWidth - left - right
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(contentWidth, 8, tr(doc.Header.OrganizationName), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if doc.Header.OrganizationAddress.Valid {
+		pdf.CellFormat(contentWidth, 5, tr(doc.Header.OrganizationAddress.String), "", 1, "C", false, 0, "")
+	}
+	if doc.Header.OrganizationPanNumber.Valid {
+		pdf.CellFormat(contentWidth, 5, "PAN: "+strconv.Itoa(int(doc.Header.OrganizationPanNumber.Int32)), "", 1, "C", false, 0, "")
+	}
+	branch := doc.Header.BranchName
+	if doc.Header.BranchAddress.Valid {
+		branch += ", " + doc.Header.BranchAddress.String
+	}
+	if doc.Header.BranchContactNumber.Valid {
+		branch += " - Tel: " + doc.Header.BranchContactNumber.String
+	}
+	pdf.CellFormat(contentWidth, 5, tr(branch), "", 1, "C", false, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(contentWidth, 7, doc.Title(), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	half := contentWidth / 2
+	pdf.CellFormat(half, 5, "No: "+doc.Number, "", 0, "L", false, 0, "")
+	pdf.CellFormat(half, 5, "Date: "+doc.Sale.SoldDate.Format("2006-01-02 15:04"), "", 1, "R", false, 0, "")
+	if doc.Sale.CustomerName.Valid {
+		pdf.CellFormat(contentWidth, 5, tr("Customer: "+doc.Sale.CustomerName.String), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	widths := []float64{contentWidth - 90, 25, 30, 35}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, heading := range []string{"Item", "Qty", "Unit price", "Amount"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(widths[i], 7, heading, "B", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, l := range doc.Lines {
+		// Long names wrap within the Item column below the line's figures
+		names := pdf.SplitLines([]byte(tr(l.ProductName)), widths[0])
+		if len(names) == 0 {
+			names = [][]byte{nil}
+		}
+		pdf.CellFormat(widths[0], 6, string(names[0]), "", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, tr(l.SoldQuantity.String()+" "+l.SoldUnit), "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 6, soldPrice(l).StringFixed(amountPlaces), "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, l.Total.StringFixed(amountPlaces), "", 1, "R", false, 0, "")
+		for _, name := range names[1:] {
+			pdf.CellFormat(widths[0], 6, string(name), "", 1, "L", false, 0, "")
+		}
+	}
+
+	border := "T"
+	if !doc.Sale.TaxAmount.IsZero() {
+		pdf.CellFormat(contentWidth-widths[3], 6, "Taxable amount", "T", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, doc.Sale.TaxableAmount.StringFixed(amountPlaces), "T", 1, "R", false, 0, "")
+		pdf.CellFormat(contentWidth-widths[3], 6, "Tax", "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, doc.Sale.TaxAmount.StringFixed(amountPlaces), "", 1, "R", false, 0, "")
+		border = ""
+	}
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(contentWidth-widths[3], 8, "Total", border, 0, "R", false, 0, "")
+	pdf.CellFormat(widths[3], 8, doc.Sale.TotalAmount.StringFixed(amountPlaces), border, 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, t := range doc.Tenders {
+		pdf.CellFormat(contentWidth-widths[3], 6, tr("Paid by "+t.Name), "", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, t.Amount.StringFixed(amountPlaces), "", 1, "R", false, 0, "")
+		if t.ChangeDue.IsPositive() {
+			pdf.CellFormat(contentWidth-widths[3], 6, "Tendered", "", 0, "R", false, 0, "")
+			pdf.CellFormat(widths[3], 6, t.Tendered.StringFixed(amountPlaces), "", 1, "R", false, 0, "")
+			pdf.CellFormat(contentWidth-widths[3], 6, "Change", "", 0, "R", false, 0, "")
+			pdf.CellFormat(widths[3], 6, t.ChangeDue.StringFixed(amountPlaces), "", 1, "R", false, 0, "")
+		}
+	}
+	if len(doc.Tenders) == 0 && doc.Sale.PaymentMethod.Valid {
+		pdf.CellFormat(contentWidth, 6, tr("Paid by "+doc.Sale.PaymentMethod.String), "", 1, "R", false, 0, "")
+	}
+
+	return pdf.Output(w)
+}
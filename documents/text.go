@@ -0,0 +1,136 @@
+package documents
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Character widths of common thermal receipt printers.
+const (
+	ThermalWidth58mm = 32
+	ThermalWidth80mm = 48
+)
+
+// amountPlaces is the number of decimal places printed for money.
+const amountPlaces = 2
+
+// WriteText renders doc as plain text lines of at most width characters for
+// a thermal printer.
+func WriteText(w io.Writer, doc Document, width int) error {
+	if width < 24 {
+		width = 24
+	}
+	var b strings.Builder
+	rule := strings.Repeat("-", width)
+	line := func(s string) { b.WriteString(s); b.WriteByte('\n') }
+
+	for _, s := range wrap(doc.Header.OrganizationName, width) {
+		line(center(s, width))
+	}
+	if doc.Header.OrganizationAddress.Valid {
+		for _, s := range wrap(doc.Header.OrganizationAddress.String, width) {
+			line(center(s, width))
+		}
+	}
+	if doc.Header.OrganizationPanNumber.Valid {
+		line(center("PAN: "+strconv.Itoa(int(doc.Header.OrganizationPanNumber.Int32)), width))
+	}
+	line(center(doc.Header.BranchName, width))
+	if doc.Header.BranchAddress.Valid {
+		for _, s := range wrap(doc.Header.BranchAddress.String, width) {
+			line(center(s, width))
+		}
+	}
+	if doc.Header.BranchContactNumber.Valid {
+		line(center("Tel: "+doc.Header.BranchContactNumber.String, width))
+	}
+	line(rule)
+	line(center(doc.Title(), width))
+	line(columns("No:", doc.Number, width))
+	line(columns("Date:", doc.Sale.SoldDate.Format("2006-01-02 15:04"), width))
+	if doc.Sale.CustomerName.Valid {
+		line(columns("Customer:", doc.Sale.CustomerName.String, width))
+	}
+	line(rule)
+	for _, l := range doc.Lines {
+		for _, s := range wrap(l.ProductName, width) {
+			line(s)
+		}
+		qty := fmt.Sprintf("  %s %s x %s", l.SoldQuantity.String(), l.SoldUnit, soldPrice(l).StringFixed(amountPlaces))
+		line(columns(qty, l.Total.StringFixed(amountPlaces), width))
+	}
+	line(rule)
+	if !doc.Sale.TaxAmount.IsZero() {
+		line(columns("Taxable", doc.Sale.TaxableAmount.StringFixed(amountPlaces), width))
+		line(columns("Tax", doc.Sale.TaxAmount.StringFixed(amountPlaces), width))
+	}
+	line(columns("TOTAL", doc.Sale.TotalAmount.StringFixed(amountPlaces), width))
+	for _, t := range doc.Tenders {
+		line(columns("Paid by "+t.Name, t.Amount.StringFixed(amountPlaces), width))
+		if t.ChangeDue.IsPositive() {
+			line(columns("  Tendered", t.Tendered.StringFixed(amountPlaces), width))
+			line(columns("  Change", t.ChangeDue.StringFixed(amountPlaces), width))
+		}
+	}
+	if len(doc.Tenders) == 0 && doc.Sale.PaymentMethod.Valid {
+		line(columns("Paid by", doc.Sale.PaymentMethod.String, width))
+	}
+	line(rule)
+	line(center("Thank you", width))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// columns places left and right on one line of width, moving right to its
+// own line when both do not fit.
+func columns(left, right string, width int) string {
+	gap := width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if gap < 1 {
+		return left + "\n" + strings.Repeat(" ", max(width-utf8.RuneCountInString(right), 0)) + right
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// center pads s to sit in the middle of width.
+func center(s string, width int) string {
+	pad := (width - utf8.RuneCountInString(s)) / 2
+	if pad <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", pad) + s
+}
+
+// wrap splits text into lines of at most width characters at spaces,
+// breaking words longer than a line.
+func wrap(text string, width int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:width]))
+			word = string(r[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,68 @@
+package documents
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 10, nil},
+		{"   ", 10, nil},
+		{"hello world", 5, []string{"hello", "world"}},
+		{"a b c", 3, []string{"a b", "c"}},
+		{"  spaced   out  ", 20, []string{"spaced out"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"ab abcdefg", 4, []string{"ab", "abcd", "efg"}},
+		{"ñandú ñu", 5, []string{"ñandú", "ñu"}},
+	}
+	for _, tt := range tests {
+		if got := wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		left, right string
+		width       int
+		want        string
+	}{
+		{"No:", "42", 10, "No:     42"},
+		{"Tax", "1.30", 8, "Tax 1.30"},
+		{"ab", "cd", 4, "ab\n  cd"},
+		{"Customer:", "Someone Long", 12, "Customer:\nSomeone Long"},
+		{"Customer:", "Someone Longer", 12, "Customer:\nSomeone Longer"},
+		{"Total", "ñ", 7, "Total ñ"},
+	}
+	for _, tt := range tests {
+		if got := columns(tt.left, tt.right, tt.width); got != tt.want {
+			t.Errorf("columns(%q, %q, %d) = %q, want %q", tt.left, tt.right, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		docType generated.DocumentType
+		branch  string
+		number  int64
+		want    string
+	}{
+		{generated.DocumentTypeInvoice, "main", 42, "INV-main-000042"},
+		{generated.DocumentTypeReceipt, "main", 1, "RCT-main-000001"},
+		{generated.DocumentTypeInvoice, "north", 1234567, "INV-north-1234567"},
+	}
+	for _, tt := range tests {
+		if got := FormatNumber(tt.docType, tt.branch, tt.number); got != tt.want {
+			t.Errorf("FormatNumber(%s, %q, %d) = %q, want %q", tt.docType, tt.branch, tt.number, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Package exporter writes all data of an organization to a zip archive.
+//
+// Each table becomes one CSV or JSON Lines file in the archive, next to a
+// manifest.json listing the row counts. Tables are read through
+// server-side cursors in one read-only, repeatable-read transaction, so the
+// files agree with each other and memory stays flat however large the
+// organization is.
+package exporter
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// fetchSize is the number of rows fetched from a cursor at a time.
+const fetchSize = 1000
+
+// ErrUnknownFormat is returned when exporting to a format other than csv or jsonl.
+var ErrUnknownFormat = errors.New("exporter: unknown export format")
+
+// exporterRoles may export the data of their organization.
+var exporterRoles = []access.Role{access.RoleAdmin, access.RoleAdminReadOnly}
+
+// table is an exported table and the query reading its rows of one
+// organization, given as $1.
+type table struct {
+	name  string
+	query string
+}
+
+var tables = []table{
+	{"products", `SELECT * FROM products WHERE organization_id = $1 ORDER BY product_id`},
+	{"purchase_groups", `SELECT * FROM purchase_group WHERE organization_id = $1 ORDER BY purchase_group_id`},
+	{"purchases", `SELECT * FROM purchases WHERE organization_id = $1 ORDER BY purchase_id`},
+	{"sales_groups", `SELECT * FROM sales_group WHERE organization_id = $1 ORDER BY sales_group_id`},
+	{"sales", `SELECT s.*
+		FROM sales s
+		         INNER JOIN sales_group sg ON sg.sales_group_id = s.sales_group_id
+		WHERE sg.organization_id = $1
+		ORDER BY s.sales_id`},
+	{"partners", `SELECT * FROM partners WHERE organization_id = $1 ORDER BY partner_id`},
+	{"partner_payment_receipts", `SELECT * FROM partner_payment_receipt WHERE organization_id = $1 ORDER BY pr_id`},
+	{"activity", `SELECT * FROM activity WHERE organization_id = $1 ORDER BY seq`},
+}
+
+// Manifest describes an export. It is written to the archive as
+// manifest.json.
+type Manifest struct {
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	Format         Format           `json:"format"`
+	ExportedAt     time.Time        `json:"exported_at"`
+	Rows           map[string]int64 `json:"rows"`
+}
+
+// Service exports organization data.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns an exporter Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Export writes every row of an organization's products, purchases, sales,
+// partners, payment receipts and activity to w as a zip archive of format
+// files. A completed export is recorded as a read activity. Only admin and
+// adminReadOnly users of the organization may export.
+func (s *Service) Export(ctx context.Context, organizationID, userProfileID uuid.UUID, format Format, w io.Writer) (Manifest, error) {
+	if format != FormatCSV && format != FormatJSONL {
+		return Manifest{}, fmt.Errorf("%q: %w", format, ErrUnknownFormat)
+	}
+	q := s.db.Queries()
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if !actor.HasRole(exporterRoles...) {
+		return Manifest{}, fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	manifest := Manifest{
+		OrganizationID: organizationID,
+		Format:         format,
+		ExportedAt:     time.Now().UTC(),
+		Rows:           make(map[string]int64, len(tables)),
+	}
+	tx, err := s.db.Conn().BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return manifest, fmt.Errorf("begin export transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	zw := zip.NewWriter(w)
+	for _, t := range tables {
+		f, err := zw.Create(t.name + "." + string(format))
+		if err != nil {
+			return manifest, fmt.Errorf("create %s: %w", t.name, err)
+		}
+		n, err := exportTable(ctx, tx, t, organizationID, format, f)
+		if err != nil {
+			return manifest, fmt.Errorf("export %s: %w", t.name, err)
+		}
+		manifest.Rows[t.name] = n
+	}
+	f, err := zw.Create("manifest.json")
+	if err != nil {
+		return manifest, fmt.Errorf("create manifest: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return manifest, fmt.Errorf("write manifest: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return manifest, fmt.Errorf("finish archive: %w", err)
+	}
+	// Recorded once the archive is complete, so failed exports leave no
+	// activity and the snapshot never contains its own export.
+	err = q.InsertActivity(ctx, generated.InsertActivityParams{
+		Identity:       actor.Email,
+		Operation:      generated.OperationTypeRead,
+		Resource:       []string{"organization", "export", string(format)},
+		OldValue:       []string{},
+		NewValue:       []string{},
+		Status:         true,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return manifest, fmt.Errorf("record export activity: %w", err)
+	}
+	return manifest, nil
+}
+
+// exportTable streams the rows of t through a cursor to w.
+func exportTable(ctx context.Context, tx *sql.Tx, t table, organizationID uuid.UUID, format Format, w io.Writer) (int64, error) {
+	// DECLARE takes no bind parameters; a uuid.UUID always formats as a
+	// plain literal.
+	query := strings.ReplaceAll(t.query, "$1", pq.QuoteLiteral(organizationID.String())+"::uuid")
+	if _, err := tx.ExecContext(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+query); err != nil {
+		return 0, fmt.Errorf("declare cursor: %w", err)
+	}
+
+	// start runs once with the columns of the first fetch, even when the
+	// table has no rows.
+	start := func([]*sql.ColumnType) error { return nil }
+	var write func(columns []*sql.ColumnType, values []any) error
+	var flush func() error
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		start = func(columns []*sql.ColumnType) error {
+			names := make([]string, len(columns))
+			for i, c := range columns {
+				names[i] = c.Name()
+			}
+			return cw.Write(names)
+		}
+		write = func(columns []*sql.ColumnType, values []any) error {
+			record := make([]string, len(values))
+			for i, v := range values {
+				record[i] = csvValue(columns[i], v)
+			}
+			return cw.Write(record)
+		}
+		flush = func() error { cw.Flush(); return cw.Error() }
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(columns []*sql.ColumnType, values []any) error {
+			row := make(map[string]any, len(values))
+			for i, v := range values {
+				row[columns[i].Name()] = jsonValue(columns[i], v)
+			}
+			return enc.Encode(row)
+		}
+		flush = func() error { return nil }
+	}
+
+	var written int64
+	for {
+		fetched, err := fetch(ctx, tx, start, write)
+		if err != nil {
+			return written, err
+		}
+		start = nil
+		written += fetched
+		if fetched < fetchSize {
+			break
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "CLOSE export_cursor"); err != nil {
+		return written, fmt.Errorf("close cursor: %w", err)
+	}
+	return written, flush()
+}
+
+// fetch reads the next batch of rows from the cursor and writes each one,
+// after passing the columns to start when it is not nil.
+func fetch(ctx context.Context, tx *sql.Tx, start func([]*sql.ColumnType) error, write func([]*sql.ColumnType, []any) error) (int64, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("FETCH %d FROM export_cursor", fetchSize))
+	if err != nil {
+		return 0, fmt.Errorf("fetch rows: %w", err)
+	}
+	defer rows.Close()
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, fmt.Errorf("read columns: %w", err)
+	}
+	if start != nil {
+		if err := start(columns); err != nil {
+			return 0, fmt.Errorf("write header: %w", err)
+		}
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return n, fmt.Errorf("scan row: %w", err)
+		}
+		if err := write(columns, values); err != nil {
+			return n, fmt.Errorf("write row: %w", err)
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// jsonValue converts a scanned value for JSON Lines. Numbers keep their
+// exact decimal digits, text arrays become JSON arrays and JSON columns are
+// embedded as they are.
+func jsonValue(column *sql.ColumnType, v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		switch typ := column.DatabaseTypeName(); {
+		case typ == "NUMERIC":
+			return json.Number(v)
+		case typ == "JSON" || typ == "JSONB":
+			return json.RawMessage(v)
+		case isTextArray(typ):
+			var elements pq.StringArray
+			if err := elements.Scan(v); err == nil {
+				return []string(elements)
+			}
+		}
+		return string(v)
+	}
+	return v
+}
+
+// csvValue converts a scanned value for CSV. Text arrays are written as JSON
+// arrays so that elements containing commas survive the round trip.
+func csvValue(column *sql.ColumnType, v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		if isTextArray(column.DatabaseTypeName()) {
+			var elements pq.StringArray
+			if err := elements.Scan(v); err == nil {
+				encoded, _ := json.Marshal([]string(elements))
+				return string(encoded)
+			}
+		}
+		return string(v)
+	}
+	return fmt.Sprint(v)
+}
+
+func isTextArray(typ string) bool {
+	return typ == "_TEXT" || typ == "_VARCHAR"
+}
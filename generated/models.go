@@ -7,6 +7,7 @@ package generated
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -14,6 +15,178 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type BulkLoadTable string
+
+const (
+	BulkLoadTablePurchases  BulkLoadTable = "purchases"
+	BulkLoadTableSales      BulkLoadTable = "sales"
+	BulkLoadTableSalesGroup BulkLoadTable = "sales_group"
+)
+
+func (e *BulkLoadTable) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BulkLoadTable(s)
+	case string:
+		*e = BulkLoadTable(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BulkLoadTable: %T", src)
+	}
+	return nil
+}
+
+type NullBulkLoadTable struct {
+	BulkLoadTable BulkLoadTable `json:"bulk_load_table"`
+	Valid         bool          `json:"valid"` // Valid is true if BulkLoadTable is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBulkLoadTable) Scan(value interface{}) error {
+	if value == nil {
+		ns.BulkLoadTable, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BulkLoadTable.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBulkLoadTable) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BulkLoadTable), nil
+}
+
+type CostingMethod string
+
+const (
+	CostingMethodWeightedAverage CostingMethod = "weighted_average"
+	CostingMethodFifo            CostingMethod = "fifo"
+)
+
+func (e *CostingMethod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CostingMethod(s)
+	case string:
+		*e = CostingMethod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CostingMethod: %T", src)
+	}
+	return nil
+}
+
+type NullCostingMethod struct {
+	CostingMethod CostingMethod `json:"costing_method"`
+	Valid         bool          `json:"valid"` // Valid is true if CostingMethod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCostingMethod) Scan(value interface{}) error {
+	if value == nil {
+		ns.CostingMethod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CostingMethod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCostingMethod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CostingMethod), nil
+}
+
+type DocumentType string
+
+const (
+	DocumentTypeInvoice DocumentType = "invoice"
+	DocumentTypeReceipt DocumentType = "receipt"
+)
+
+func (e *DocumentType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DocumentType(s)
+	case string:
+		*e = DocumentType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DocumentType: %T", src)
+	}
+	return nil
+}
+
+type NullDocumentType struct {
+	DocumentType DocumentType `json:"document_type"`
+	Valid        bool         `json:"valid"` // Valid is true if DocumentType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDocumentType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DocumentType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DocumentType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDocumentType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DocumentType), nil
+}
+
+type MovementType string
+
+const (
+	MovementTypePurchase   MovementType = "purchase"
+	MovementTypeSale       MovementType = "sale"
+	MovementTypeReturn     MovementType = "return"
+	MovementTypeAdjustment MovementType = "adjustment"
+	MovementTypeTransfer   MovementType = "transfer"
+)
+
+func (e *MovementType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MovementType(s)
+	case string:
+		*e = MovementType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MovementType: %T", src)
+	}
+	return nil
+}
+
+type NullMovementType struct {
+	MovementType MovementType `json:"movement_type"`
+	Valid        bool         `json:"valid"` // Valid is true if MovementType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMovementType) Scan(value interface{}) error {
+	if value == nil {
+		ns.MovementType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MovementType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMovementType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MovementType), nil
+}
+
 type OperationType string
 
 const (
@@ -59,6 +232,393 @@ func (ns NullOperationType) Value() (driver.Value, error) {
 	return string(ns.OperationType), nil
 }
 
+type PartnerType string
+
+const (
+	PartnerTypeSupplier PartnerType = "supplier"
+	PartnerTypeCustomer PartnerType = "customer"
+	PartnerTypeBoth     PartnerType = "both"
+)
+
+func (e *PartnerType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PartnerType(s)
+	case string:
+		*e = PartnerType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PartnerType: %T", src)
+	}
+	return nil
+}
+
+type NullPartnerType struct {
+	PartnerType PartnerType `json:"partner_type"`
+	Valid       bool        `json:"valid"` // Valid is true if PartnerType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPartnerType) Scan(value interface{}) error {
+	if value == nil {
+		ns.PartnerType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PartnerType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPartnerType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PartnerType), nil
+}
+
+type PaymentMethodKind string
+
+const (
+	PaymentMethodKindCash   PaymentMethodKind = "cash"
+	PaymentMethodKindCard   PaymentMethodKind = "card"
+	PaymentMethodKindBank   PaymentMethodKind = "bank"
+	PaymentMethodKindCredit PaymentMethodKind = "credit"
+	PaymentMethodKindOther  PaymentMethodKind = "other"
+)
+
+func (e *PaymentMethodKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentMethodKind(s)
+	case string:
+		*e = PaymentMethodKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentMethodKind: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentMethodKind struct {
+	PaymentMethodKind PaymentMethodKind `json:"payment_method_kind"`
+	Valid             bool              `json:"valid"` // Valid is true if PaymentMethodKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentMethodKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentMethodKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentMethodKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentMethodKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentMethodKind), nil
+}
+
+type PriceChangeKind string
+
+const (
+	PriceChangeKindChange    PriceChangeKind = "change"
+	PriceChangeKindPromotion PriceChangeKind = "promotion"
+)
+
+func (e *PriceChangeKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PriceChangeKind(s)
+	case string:
+		*e = PriceChangeKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PriceChangeKind: %T", src)
+	}
+	return nil
+}
+
+type NullPriceChangeKind struct {
+	PriceChangeKind PriceChangeKind `json:"price_change_kind"`
+	Valid           bool            `json:"valid"` // Valid is true if PriceChangeKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPriceChangeKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PriceChangeKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PriceChangeKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPriceChangeKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PriceChangeKind), nil
+}
+
+type PriceMode string
+
+const (
+	PriceModeTaxExclusive PriceMode = "tax_exclusive"
+	PriceModeTaxInclusive PriceMode = "tax_inclusive"
+)
+
+func (e *PriceMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PriceMode(s)
+	case string:
+		*e = PriceMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PriceMode: %T", src)
+	}
+	return nil
+}
+
+type NullPriceMode struct {
+	PriceMode PriceMode `json:"price_mode"`
+	Valid     bool      `json:"valid"` // Valid is true if PriceMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPriceMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.PriceMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PriceMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPriceMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PriceMode), nil
+}
+
+type ProductCodeType string
+
+const (
+	ProductCodeTypeBarcode ProductCodeType = "barcode"
+	ProductCodeTypeSku     ProductCodeType = "sku"
+)
+
+func (e *ProductCodeType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProductCodeType(s)
+	case string:
+		*e = ProductCodeType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProductCodeType: %T", src)
+	}
+	return nil
+}
+
+type NullProductCodeType struct {
+	ProductCodeType ProductCodeType `json:"product_code_type"`
+	Valid           bool            `json:"valid"` // Valid is true if ProductCodeType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProductCodeType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProductCodeType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProductCodeType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProductCodeType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProductCodeType), nil
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "draft"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "sent"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "closed"
+)
+
+func (e *PurchaseOrderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PurchaseOrderStatus(s)
+	case string:
+		*e = PurchaseOrderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PurchaseOrderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPurchaseOrderStatus struct {
+	PurchaseOrderStatus PurchaseOrderStatus `json:"purchase_order_status"`
+	Valid               bool                `json:"valid"` // Valid is true if PurchaseOrderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPurchaseOrderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PurchaseOrderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PurchaseOrderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPurchaseOrderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PurchaseOrderStatus), nil
+}
+
+type ShiftStatus string
+
+const (
+	ShiftStatusOpen   ShiftStatus = "open"
+	ShiftStatusClosed ShiftStatus = "closed"
+)
+
+func (e *ShiftStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShiftStatus(s)
+	case string:
+		*e = ShiftStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShiftStatus: %T", src)
+	}
+	return nil
+}
+
+type NullShiftStatus struct {
+	ShiftStatus ShiftStatus `json:"shift_status"`
+	Valid       bool        `json:"valid"` // Valid is true if ShiftStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShiftStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShiftStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShiftStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShiftStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShiftStatus), nil
+}
+
+type StocktakeStatus string
+
+const (
+	StocktakeStatusOpen      StocktakeStatus = "open"
+	StocktakeStatusPosted    StocktakeStatus = "posted"
+	StocktakeStatusCancelled StocktakeStatus = "cancelled"
+)
+
+func (e *StocktakeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StocktakeStatus(s)
+	case string:
+		*e = StocktakeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StocktakeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStocktakeStatus struct {
+	StocktakeStatus StocktakeStatus `json:"stocktake_status"`
+	Valid           bool            `json:"valid"` // Valid is true if StocktakeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStocktakeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StocktakeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StocktakeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStocktakeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StocktakeStatus), nil
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusDraft      TransferStatus = "draft"
+	TransferStatusDispatched TransferStatus = "dispatched"
+	TransferStatusReceived   TransferStatus = "received"
+	TransferStatusCancelled  TransferStatus = "cancelled"
+)
+
+func (e *TransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferStatus(s)
+	case string:
+		*e = TransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferStatus struct {
+	TransferStatus TransferStatus `json:"transfer_status"`
+	Valid          bool           `json:"valid"` // Valid is true if TransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferStatus), nil
+}
+
 type Activity struct {
 	ID             uuid.UUID     `json:"id"`
 	Identity       string        `json:"identity"`
@@ -69,6 +629,9 @@ type Activity struct {
 	Status         bool          `json:"status"`
 	Time           time.Time     `json:"time"`
 	OrganizationID uuid.UUID     `json:"organization_id"`
+	Seq            int64         `json:"seq"`
+	PrevHash       string        `json:"prev_hash"`
+	RowHash        string        `json:"row_hash"`
 }
 
 type Auth struct {
@@ -81,10 +644,25 @@ type Auth struct {
 }
 
 type Branch struct {
-	ID             uuid.UUID `json:"id"`
-	UniqueName     string    `json:"unique_name"`
-	BranchName     string    `json:"branch_name"`
-	OrganizationID uuid.UUID `json:"organization_id"`
+	ID             uuid.UUID      `json:"id"`
+	UniqueName     string         `json:"unique_name"`
+	BranchName     string         `json:"branch_name"`
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	Address        sql.NullString `json:"address"`
+	ContactNumber  sql.NullString `json:"contact_number"`
+}
+
+type BulkLoad struct {
+	OrganizationID uuid.UUID     `json:"organization_id"`
+	LoadName       string        `json:"load_name"`
+	TableName      BulkLoadTable `json:"table_name"`
+	RowsRead       int64         `json:"rows_read"`
+	RowsLoaded     int64         `json:"rows_loaded"`
+	RowsRejected   int64         `json:"rows_rejected"`
+	StartedBy      uuid.UUID     `json:"started_by"`
+	StartedAt      time.Time     `json:"started_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	CompletedAt    sql.NullTime  `json:"completed_at"`
 }
 
 type Configuration struct {
@@ -92,9 +670,57 @@ type Configuration struct {
 	LatestMigration string `json:"latest_migration"`
 }
 
+type CostAllocation struct {
+	AllocationID uuid.UUID       `json:"allocation_id"`
+	LayerID      uuid.UUID       `json:"layer_id"`
+	ReferenceID  uuid.UUID       `json:"reference_id"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	UnitCost     decimal.Decimal `json:"unit_cost"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type CostLayer struct {
+	LayerID           uuid.UUID       `json:"layer_id"`
+	ProductID         uuid.UUID       `json:"product_id"`
+	BranchUuid        uuid.UUID       `json:"branch_uuid"`
+	OrganizationID    uuid.UUID       `json:"organization_id"`
+	ReferenceID       uuid.NullUUID   `json:"reference_id"`
+	UnitCost          decimal.Decimal `json:"unit_cost"`
+	Quantity          decimal.Decimal `json:"quantity"`
+	RemainingQuantity decimal.Decimal `json:"remaining_quantity"`
+	ReceivedAt        time.Time       `json:"received_at"`
+}
+
+type DocumentSequence struct {
+	BranchUuid   uuid.UUID    `json:"branch_uuid"`
+	DocumentType DocumentType `json:"document_type"`
+	LastNumber   int64        `json:"last_number"`
+}
+
+type GoodsReceivedLine struct {
+	GrnLineID        uuid.UUID       `json:"grn_line_id"`
+	GrnID            uuid.UUID       `json:"grn_id"`
+	OrderLineID      uuid.UUID       `json:"order_line_id"`
+	ReceivedQuantity decimal.Decimal `json:"received_quantity"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+}
+
+type GoodsReceivedNote struct {
+	GrnID           uuid.UUID      `json:"grn_id"`
+	OrderID         uuid.UUID      `json:"order_id"`
+	PurchaseGroupID uuid.UUID      `json:"purchase_group_id"`
+	ReceivedBy      uuid.UUID      `json:"received_by"`
+	Comments        sql.NullString `json:"comments"`
+	ReceivedAt      time.Time      `json:"received_at"`
+}
+
 type Organization struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID            uuid.UUID      `json:"id"`
+	Name          string         `json:"name"`
+	CostingMethod CostingMethod  `json:"costing_method"`
+	PanNumber     sql.NullInt32  `json:"pan_number"`
+	Address       sql.NullString `json:"address"`
+	PriceMode     PriceMode      `json:"price_mode"`
 }
 
 type Partner struct {
@@ -107,6 +733,7 @@ type Partner struct {
 	Email          sql.NullString `json:"email"`
 	BranchUuid     uuid.UUID      `json:"branch_uuid"`
 	OrganizationID uuid.UUID      `json:"organization_id"`
+	PartnerType    PartnerType    `json:"partner_type"`
 }
 
 type PartnerPaymentReceipt struct {
@@ -118,6 +745,15 @@ type PartnerPaymentReceipt struct {
 	UserProfileID  uuid.UUID       `json:"user_profile_id"`
 	Comments       sql.NullString  `json:"comments"`
 	OrganizationID uuid.UUID       `json:"organization_id"`
+	RecordedAt     time.Time       `json:"recorded_at"`
+}
+
+type PaymentMethod struct {
+	MethodID       uuid.UUID         `json:"method_id"`
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	Name           string            `json:"name"`
+	Kind           PaymentMethodKind `json:"kind"`
+	IsActive       bool              `json:"is_active"`
 }
 
 type Product struct {
@@ -131,6 +767,47 @@ type Product struct {
 	BranchUuid        uuid.UUID       `json:"branch_uuid"`
 	MeasurementUnit   string          `json:"measurement_unit"`
 	OrganizationID    uuid.UUID       `json:"organization_id"`
+	AverageCost       decimal.Decimal `json:"average_cost"`
+	ReorderPoint      decimal.Decimal `json:"reorder_point"`
+	ReorderQuantity   decimal.Decimal `json:"reorder_quantity"`
+	TaxRateID         uuid.NullUUID   `json:"tax_rate_id"`
+}
+
+type ProductCode struct {
+	CodeID         uuid.UUID       `json:"code_id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Code           string          `json:"code"`
+	CodeType       ProductCodeType `json:"code_type"`
+	UniqueName     string          `json:"unique_name"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type ProductPrice struct {
+	PriceID        uuid.UUID       `json:"price_id"`
+	ProductID      uuid.UUID       `json:"product_id"`
+	BranchUuid     uuid.UUID       `json:"branch_uuid"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Kind           PriceChangeKind `json:"kind"`
+	SellingPrice   decimal.Decimal `json:"selling_price"`
+	StartsAt       time.Time       `json:"starts_at"`
+	EndsAt         sql.NullTime    `json:"ends_at"`
+	CreatedBy      uuid.NullUUID   `json:"created_by"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type ProductUnit struct {
+	ProductID uuid.UUID       `json:"product_id"`
+	UnitName  string          `json:"unit_name"`
+	Factor    decimal.Decimal `json:"factor"`
+}
+
+type ProductVariant struct {
+	VariantID        uuid.UUID       `json:"variant_id"`
+	OrganizationID   uuid.UUID       `json:"organization_id"`
+	UniqueName       string          `json:"unique_name"`
+	ParentUniqueName string          `json:"parent_unique_name"`
+	Options          json.RawMessage `json:"options"`
+	CreatedAt        time.Time       `json:"created_at"`
 }
 
 type Purchase struct {
@@ -142,6 +819,8 @@ type Purchase struct {
 	Units             decimal.Decimal `json:"units"`
 	BranchUuid        uuid.UUID       `json:"branch_uuid"`
 	OrganizationID    uuid.UUID       `json:"organization_id"`
+	PurchasedUnit     string          `json:"purchased_unit"`
+	PurchasedQuantity decimal.Decimal `json:"purchased_quantity"`
 }
 
 type PurchaseGroup struct {
@@ -157,6 +836,39 @@ type PurchaseGroup struct {
 	OrganizationID  uuid.UUID       `json:"organization_id"`
 }
 
+type PurchaseOrder struct {
+	OrderID        uuid.UUID           `json:"order_id"`
+	PartnerID      uuid.UUID           `json:"partner_id"`
+	BranchUuid     uuid.UUID           `json:"branch_uuid"`
+	OrganizationID uuid.UUID           `json:"organization_id"`
+	Status         PurchaseOrderStatus `json:"status"`
+	CreatedBy      uuid.UUID           `json:"created_by"`
+	ExpectedDate   sql.NullTime        `json:"expected_date"`
+	Comments       sql.NullString      `json:"comments"`
+	CreatedAt      time.Time           `json:"created_at"`
+	SentAt         sql.NullTime        `json:"sent_at"`
+	ClosedAt       sql.NullTime        `json:"closed_at"`
+}
+
+type PurchaseOrderLine struct {
+	LineID           uuid.UUID       `json:"line_id"`
+	OrderID          uuid.UUID       `json:"order_id"`
+	UniqueName       string          `json:"unique_name"`
+	ProductName      string          `json:"product_name"`
+	MeasurementUnit  string          `json:"measurement_unit"`
+	OrderedQuantity  decimal.Decimal `json:"ordered_quantity"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+	SellingPrice     decimal.Decimal `json:"selling_price"`
+	ReceivedQuantity decimal.Decimal `json:"received_quantity"`
+}
+
+type PurchaseTender struct {
+	TenderID        uuid.UUID       `json:"tender_id"`
+	PurchaseGroupID uuid.UUID       `json:"purchase_group_id"`
+	MethodID        uuid.UUID       `json:"method_id"`
+	Amount          decimal.Decimal `json:"amount"`
+}
+
 type Sale struct {
 	SalesID          uuid.UUID       `json:"sales_id"`
 	SalesGroupID     uuid.NullUUID   `json:"sales_group_id"`
@@ -166,6 +878,22 @@ type Sale struct {
 	SalesPrice       decimal.Decimal `json:"sales_price"`
 	Total            decimal.Decimal `json:"total"`
 	Profit           decimal.Decimal `json:"profit"`
+	TaxRate          decimal.Decimal `json:"tax_rate"`
+	TaxableAmount    decimal.Decimal `json:"taxable_amount"`
+	TaxAmount        decimal.Decimal `json:"tax_amount"`
+	SoldUnit         string          `json:"sold_unit"`
+	SoldQuantity     decimal.Decimal `json:"sold_quantity"`
+}
+
+type SalesDocument struct {
+	DocumentID     uuid.UUID    `json:"document_id"`
+	SalesGroupID   uuid.UUID    `json:"sales_group_id"`
+	BranchUuid     uuid.UUID    `json:"branch_uuid"`
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	DocumentType   DocumentType `json:"document_type"`
+	DocumentNumber int64        `json:"document_number"`
+	IssuedBy       uuid.UUID    `json:"issued_by"`
+	IssuedAt       time.Time    `json:"issued_at"`
 }
 
 type SalesGroup struct {
@@ -179,6 +907,129 @@ type SalesGroup struct {
 	OrganizationID uuid.UUID       `json:"organization_id"`
 	CustomerName   sql.NullString  `json:"customer_name"`
 	Comments       sql.NullString  `json:"comments"`
+	PriceMode      PriceMode       `json:"price_mode"`
+	TaxableAmount  decimal.Decimal `json:"taxable_amount"`
+	TaxAmount      decimal.Decimal `json:"tax_amount"`
+	PartnerID      uuid.NullUUID   `json:"partner_id"`
+	ShiftID        uuid.NullUUID   `json:"shift_id"`
+}
+
+type SalesTender struct {
+	TenderID     uuid.UUID       `json:"tender_id"`
+	SalesGroupID uuid.UUID       `json:"sales_group_id"`
+	MethodID     uuid.UUID       `json:"method_id"`
+	Amount       decimal.Decimal `json:"amount"`
+	Tendered     decimal.Decimal `json:"tendered"`
+	ChangeDue    decimal.Decimal `json:"change_due"`
+}
+
+type Shift struct {
+	ShiftID        uuid.UUID       `json:"shift_id"`
+	BranchUuid     uuid.UUID       `json:"branch_uuid"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	UserProfileID  uuid.UUID       `json:"user_profile_id"`
+	Status         ShiftStatus     `json:"status"`
+	OpeningFloat   decimal.Decimal `json:"opening_float"`
+	OpenedAt       time.Time       `json:"opened_at"`
+	ExpectedCash   decimal.Decimal `json:"expected_cash"`
+	CountedCash    decimal.Decimal `json:"counted_cash"`
+	ClosedAt       sql.NullTime    `json:"closed_at"`
+	ClosedBy       uuid.NullUUID   `json:"closed_by"`
+	Comments       sql.NullString  `json:"comments"`
+}
+
+type StockAlert struct {
+	AlertID           uuid.UUID       `json:"alert_id"`
+	ProductID         uuid.UUID       `json:"product_id"`
+	BranchUuid        uuid.UUID       `json:"branch_uuid"`
+	OrganizationID    uuid.UUID       `json:"organization_id"`
+	RemainingQuantity decimal.Decimal `json:"remaining_quantity"`
+	ReorderPoint      decimal.Decimal `json:"reorder_point"`
+	ReorderQuantity   decimal.Decimal `json:"reorder_quantity"`
+	RaisedAt          time.Time       `json:"raised_at"`
+	NotifiedAt        sql.NullTime    `json:"notified_at"`
+	ResolvedAt        sql.NullTime    `json:"resolved_at"`
+}
+
+type StockAlertDelivery struct {
+	AlertID     uuid.UUID `json:"alert_id"`
+	Channel     string    `json:"channel"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+type StockMovement struct {
+	MovementID     uuid.UUID       `json:"movement_id"`
+	ProductID      uuid.UUID       `json:"product_id"`
+	BranchUuid     uuid.UUID       `json:"branch_uuid"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	MovementType   MovementType    `json:"movement_type"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	ReferenceID    uuid.NullUUID   `json:"reference_id"`
+	UserProfileID  uuid.NullUUID   `json:"user_profile_id"`
+	Comments       sql.NullString  `json:"comments"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type StockTransfer struct {
+	TransferID            uuid.UUID      `json:"transfer_id"`
+	SourceBranchUuid      uuid.UUID      `json:"source_branch_uuid"`
+	DestinationBranchUuid uuid.UUID      `json:"destination_branch_uuid"`
+	OrganizationID        uuid.UUID      `json:"organization_id"`
+	Status                TransferStatus `json:"status"`
+	CreatedBy             uuid.UUID      `json:"created_by"`
+	Comments              sql.NullString `json:"comments"`
+	CreatedAt             time.Time      `json:"created_at"`
+	DispatchedAt          sql.NullTime   `json:"dispatched_at"`
+	ReceivedAt            sql.NullTime   `json:"received_at"`
+}
+
+type StockTransferLine struct {
+	LineID               uuid.UUID       `json:"line_id"`
+	TransferID           uuid.UUID       `json:"transfer_id"`
+	SourceProductID      uuid.UUID       `json:"source_product_id"`
+	DestinationProductID uuid.NullUUID   `json:"destination_product_id"`
+	Quantity             decimal.Decimal `json:"quantity"`
+	ReceivedQuantity     decimal.Decimal `json:"received_quantity"`
+	UnitCost             decimal.Decimal `json:"unit_cost"`
+}
+
+type StocktakeCount struct {
+	CountID          uuid.UUID       `json:"count_id"`
+	SessionID        uuid.UUID       `json:"session_id"`
+	ProductID        uuid.UUID       `json:"product_id"`
+	CountedQuantity  decimal.Decimal `json:"counted_quantity"`
+	Reason           sql.NullString  `json:"reason"`
+	CountedBy        uuid.UUID       `json:"counted_by"`
+	CountedAt        time.Time       `json:"counted_at"`
+	ExpectedQuantity decimal.Decimal `json:"expected_quantity"`
+}
+
+type StocktakeSession struct {
+	SessionID      uuid.UUID       `json:"session_id"`
+	BranchUuid     uuid.UUID       `json:"branch_uuid"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Status         StocktakeStatus `json:"status"`
+	OpenedBy       uuid.UUID       `json:"opened_by"`
+	OpenedAt       time.Time       `json:"opened_at"`
+	PostedBy       uuid.NullUUID   `json:"posted_by"`
+	PostedAt       sql.NullTime    `json:"posted_at"`
+	Comments       sql.NullString  `json:"comments"`
+}
+
+type TaxRate struct {
+	TaxRateID      uuid.UUID       `json:"tax_rate_id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Name           string          `json:"name"`
+	Rate           decimal.Decimal `json:"rate"`
+	IsDefault      bool            `json:"is_default"`
+}
+
+type Unit struct {
+	UnitID         uuid.UUID       `json:"unit_id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Name           string          `json:"name"`
+	BaseUnit       string          `json:"base_unit"`
+	Factor         decimal.Decimal `json:"factor"`
 }
 
 type UserOrganizationBranch struct {
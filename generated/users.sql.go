@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const conditionalUpdateAuth = `-- name: ConditionalUpdateAuth :one
@@ -119,7 +120,7 @@ func (q *Queries) GetAllUserKeySet(ctx context.Context) ([]GetAllUserKeySetRow,
 }
 
 const getOrganization = `-- name: GetOrganization :one
-SELECT id, name
+SELECT id, name, costing_method, pan_number, address, price_mode
 FROM organization
 WHERE name = $1
 `
@@ -127,7 +128,14 @@ WHERE name = $1
 func (q *Queries) GetOrganization(ctx context.Context, name string) (Organization, error) {
 	row := q.db.QueryRowContext(ctx, getOrganization, name)
 	var i Organization
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CostingMethod,
+		&i.PanNumber,
+		&i.Address,
+		&i.PriceMode,
+	)
 	return i, err
 }
 
@@ -169,6 +177,30 @@ func (q *Queries) GetUserKeySet(ctx context.Context, userProfileID uuid.UUID) (G
 	return i, err
 }
 
+const getUserOrganizationBranch = `-- name: GetUserOrganizationBranch :one
+SELECT id, user_profile_id, organization_id, branch_uuids
+FROM user_organization_branch
+WHERE user_profile_id = $1
+  AND organization_id = $2
+`
+
+type GetUserOrganizationBranchParams struct {
+	UserProfileID  uuid.UUID `json:"user_profile_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+func (q *Queries) GetUserOrganizationBranch(ctx context.Context, arg GetUserOrganizationBranchParams) (UserOrganizationBranch, error) {
+	row := q.db.QueryRowContext(ctx, getUserOrganizationBranch, arg.UserProfileID, arg.OrganizationID)
+	var i UserOrganizationBranch
+	err := row.Scan(
+		&i.ID,
+		&i.UserProfileID,
+		&i.OrganizationID,
+		pq.Array(&i.BranchUuids),
+	)
+	return i, err
+}
+
 const getUserProfile = `-- name: GetUserProfile :one
 SELECT up.full_name, up.user_role, a.user_email, a.user_profile_id
 FROM user_profile up
@@ -198,13 +230,20 @@ func (q *Queries) GetUserProfile(ctx context.Context, userProfileID uuid.UUID) (
 const insertOrganization = `-- name: InsertOrganization :one
 INSERT INTO organization (name)
 VALUES ($1)
-    RETURNING id, name
+    RETURNING id, name, costing_method, pan_number, address, price_mode
 `
 
 func (q *Queries) InsertOrganization(ctx context.Context, name string) (Organization, error) {
 	row := q.db.QueryRowContext(ctx, insertOrganization, name)
 	var i Organization
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CostingMethod,
+		&i.PanNumber,
+		&i.Address,
+		&i.PriceMode,
+	)
 	return i, err
 }
 
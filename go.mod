@@ -8,3 +8,5 @@ require (
 )
 
 require github.com/lib/pq v1.10.9
+
+require github.com/jung-kurt/gofpdf v1.16.2
@@ -0,0 +1,260 @@
+// Package images stores product images in a blob store and keeps their key
+// in products.product_image.
+//
+// Uploads are checked for size and type, decoded, and stored with a JPEG
+// thumbnail next to them. Blobs live in a Store: DirStore keeps them on the
+// local filesystem and S3Store in an S3-compatible bucket. Products copied
+// to another branch by a stock transfer share the image of their source, so
+// a replaced image is only deleted once no product uses it.
+package images
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+const (
+	// MaxImageBytes is the largest image file accepted.
+	MaxImageBytes = 5 << 20
+	// MaxImagePixels is the largest image accepted, in pixels, so small
+	// files cannot decode into huge bitmaps.
+	MaxImagePixels = 24_000_000
+	// ThumbnailSize is the longest edge of a thumbnail, in pixels.
+	ThumbnailSize = 256
+	// thumbnailQuality is the JPEG quality of thumbnails.
+	thumbnailQuality = 85
+	// keyPrefix starts the key of every image stored by the service.
+	keyPrefix = "products/"
+)
+
+var (
+	// ErrProductNotFound is returned when a product does not exist in the organization.
+	ErrProductNotFound = errors.New("images: product not found")
+	// ErrTooLarge is returned when an image exceeds MaxImageBytes or MaxImagePixels.
+	ErrTooLarge = errors.New("images: image too large")
+	// ErrUnsupportedType is returned when an upload is not a JPEG, PNG or GIF image.
+	ErrUnsupportedType = errors.New("images: image must be JPEG, PNG or GIF")
+	// ErrNoImage is returned when a product has no image.
+	ErrNoImage = errors.New("images: product has no image")
+)
+
+// extensions maps the accepted content types to the extension of their key.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// managerRoles may change product images in their branches.
+var managerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// Service uploads and serves product images.
+type Service struct {
+	db    *store.DB
+	blobs Store
+}
+
+// NewService returns an images Service that keeps blobs in blobs.
+func NewService(db *store.DB, blobs Store) *Service {
+	return &Service{db: db, blobs: blobs}
+}
+
+// ThumbnailKey returns the key of the thumbnail stored with an image.
+func ThumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_thumb.jpg"
+}
+
+// Upload stores an image and its thumbnail and makes it the product's
+// image. The previous image is deleted when no other product uses it.
+func (s *Service) Upload(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, r io.Reader) (generated.Product, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageBytes+1))
+	if err != nil {
+		return generated.Product{}, fmt.Errorf("read image: %w", err)
+	}
+	if len(data) > MaxImageBytes {
+		return generated.Product{}, ErrTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return generated.Product{}, fmt.Errorf("%s: %w", contentType, ErrUnsupportedType)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return generated.Product{}, fmt.Errorf("decode image: %v: %w", err, ErrUnsupportedType)
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return generated.Product{}, fmt.Errorf("%dx%d: %w", config.Width, config.Height, ErrTooLarge)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return generated.Product{}, fmt.Errorf("decode image: %v: %w", err, ErrUnsupportedType)
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, ThumbnailSize), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return generated.Product{}, fmt.Errorf("encode thumbnail: %w", err)
+	}
+
+	if err := s.authorize(ctx, organizationID, userProfileID, productID); err != nil {
+		return generated.Product{}, err
+	}
+	imageID, err := uuid.NewV7()
+	if err != nil {
+		return generated.Product{}, fmt.Errorf("generate image id: %w", err)
+	}
+	key := fmt.Sprintf("%s%s/%s/%s%s", keyPrefix, organizationID, productID, imageID, ext)
+	if err := s.blobs.Put(ctx, key, data, contentType); err != nil {
+		return generated.Product{}, err
+	}
+	if err := s.blobs.Put(ctx, ThumbnailKey(key), thumb.Bytes(), "image/jpeg"); err != nil {
+		s.deleteBlobs(ctx, key)
+		return generated.Product{}, err
+	}
+
+	product, old, err := s.setImage(ctx, productID, sql.NullString{String: key, Valid: true})
+	if err != nil {
+		s.deleteBlobs(ctx, key)
+		return product, err
+	}
+	s.release(ctx, old)
+	return product, nil
+}
+
+// Remove clears a product's image. The image is deleted when no other
+// product uses it.
+func (s *Service) Remove(ctx context.Context, organizationID, userProfileID, productID uuid.UUID) (generated.Product, error) {
+	if err := s.authorize(ctx, organizationID, userProfileID, productID); err != nil {
+		return generated.Product{}, err
+	}
+	product, old, err := s.setImage(ctx, productID, sql.NullString{})
+	if err != nil {
+		return product, err
+	}
+	s.release(ctx, old)
+	return product, nil
+}
+
+// Open returns the image of a product. The caller closes it.
+func (s *Service) Open(ctx context.Context, organizationID, productID uuid.UUID) (io.ReadCloser, error) {
+	key, err := s.key(ctx, organizationID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return s.blobs.Get(ctx, key)
+}
+
+// OpenThumbnail returns the thumbnail of a product's image. The caller
+// closes it.
+func (s *Service) OpenThumbnail(ctx context.Context, organizationID, productID uuid.UUID) (io.ReadCloser, error) {
+	key, err := s.key(ctx, organizationID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return s.blobs.Get(ctx, ThumbnailKey(key))
+}
+
+func (s *Service) key(ctx context.Context, organizationID, productID uuid.UUID) (string, error) {
+	product, err := s.db.Queries().GetProductImage(ctx, generated.GetProductImageParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrProductNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get product image: %w", err)
+	}
+	if !product.ProductImage.Valid {
+		return "", ErrNoImage
+	}
+	return product.ProductImage.String, nil
+}
+
+// authorize checks the user may change the image of a product.
+func (s *Service) authorize(ctx context.Context, organizationID, userProfileID, productID uuid.UUID) error {
+	q := s.db.Queries()
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return err
+	}
+	product, err := q.GetProductImage(ctx, generated.GetProductImageParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get product image: %w", err)
+	}
+	return actor.RequireBranchRole(product.BranchUuid, managerRoles...)
+}
+
+// setImage stores key as the product's image and returns the key it
+// replaced.
+func (s *Service) setImage(ctx context.Context, productID uuid.UUID, key sql.NullString) (generated.Product, sql.NullString, error) {
+	var product generated.Product
+	var old sql.NullString
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		old, err = q.LockProductImage(ctx, productID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("lock product image: %w", err)
+		}
+		product, err = q.UpdateProductImage(ctx, generated.UpdateProductImageParams{
+			ProductImage: key,
+			ProductID:    productID,
+		})
+		if err != nil {
+			return fmt.Errorf("update product image: %w", err)
+		}
+		return nil
+	})
+	return product, old, err
+}
+
+// release deletes a replaced image once no product uses it. Values of
+// product_image not stored by the service are left alone. Failures are
+// logged; the image is already detached from the product.
+func (s *Service) release(ctx context.Context, key sql.NullString) {
+	if !key.Valid || !strings.HasPrefix(key.String, keyPrefix) {
+		return
+	}
+	uses, err := s.db.Queries().CountProductImageUses(ctx, key)
+	if err != nil {
+		log.Printf("count uses of image %s: %v", key.String, err)
+		return
+	}
+	if uses == 0 {
+		s.deleteBlobs(ctx, key.String)
+	}
+}
+
+// deleteBlobs deletes an image and its thumbnail, logging failures.
+func (s *Service) deleteBlobs(ctx context.Context, key string) {
+	for _, k := range []string{key, ThumbnailKey(key)} {
+		if err := s.blobs.Delete(ctx, k); err != nil {
+			log.Printf("delete image blob %s: %v", k, err)
+		}
+	}
+}
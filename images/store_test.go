@@ -0,0 +1,253 @@
+package images
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDirStore(t *testing.T) {
+	ctx := context.Background()
+	s := DirStore{Root: t.TempDir()}
+
+	if err := s.Put(ctx, "products/a/b.jpg", []byte("first"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put(ctx, "products/a/b.jpg", []byte("second"), "image/jpeg"); err != nil {
+		t.Fatalf("Put over existing blob: %v", err)
+	}
+	if got := readBlob(t, s, "products/a/b.jpg"); got != "second" {
+		t.Errorf("Get = %q, want %q", got, "second")
+	}
+
+	if err := s.Delete(ctx, "products/a/b.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "products/a/b.jpg"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrBlobNotFound", err)
+	}
+	if err := s.Delete(ctx, "products/a/b.jpg"); err != nil {
+		t.Errorf("Delete of missing blob: %v", err)
+	}
+}
+
+func TestDirStoreInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	s := DirStore{Root: t.TempDir()}
+	for _, key := range []string{"../escape.jpg", "products/../../escape.jpg", "/abs.jpg", ""} {
+		if err := s.Put(ctx, key, []byte("x"), "image/jpeg"); err == nil {
+			t.Errorf("Put(%q) succeeded, want an error", key)
+		}
+		if _, err := s.Get(ctx, key); err == nil || errors.Is(err, ErrBlobNotFound) {
+			t.Errorf("Get(%q): err = %v, want an invalid key error", key, err)
+		}
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3(t, "bucket", "us-east-1", "AKID", "secret")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	s := S3Store{
+		Endpoint:  server.URL,
+		Bucket:    "bucket",
+		Region:    "us-east-1",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		Client:    server.Client(),
+	}
+	const key = "products/a b+c.jpg"
+
+	if err := s.Put(ctx, key, []byte("image"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := fake.contentTypes[key]; got != "image/jpeg" {
+		t.Errorf("stored content type = %q, want image/jpeg", got)
+	}
+	if got := readBlob(t, s, key); got != "image" {
+		t.Errorf("Get = %q, want %q", got, "image")
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrBlobNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of missing blob: %v", err)
+	}
+
+	for _, msg := range fake.rejected {
+		t.Errorf("rejected request %s", msg)
+	}
+	want := []string{"PUT", "GET", "DELETE", "GET", "DELETE"}
+	if got := fake.methods; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	if got := fake.paths[0]; got != "/bucket/products/a%20b%2Bc.jpg" {
+		t.Errorf("request path = %q, want the key escaped", got)
+	}
+}
+
+func TestS3StoreRejectsBadSignature(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3(t, "bucket", "us-east-1", "AKID", "secret")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	s := S3Store{
+		Endpoint:  server.URL,
+		Bucket:    "bucket",
+		Region:    "us-east-1",
+		AccessKey: "AKID",
+		SecretKey: "wrong",
+		Client:    server.Client(),
+	}
+	err := s.Put(ctx, "products/a.jpg", []byte("image"), "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Put with a wrong secret: err = %v, want a 403 status", err)
+	}
+	if len(fake.rejected) != 1 || len(fake.objects) != 0 {
+		t.Errorf("rejected %v and stored %d objects, want one rejection and none stored", fake.rejected, len(fake.objects))
+	}
+}
+
+func readBlob(t *testing.T, s Store, key string) string {
+	t.Helper()
+	r, err := s.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read %q: %v", key, err)
+	}
+	return string(data)
+}
+
+// fakeS3 is an in-memory, path-style S3 bucket that checks the Signature
+// Version 4 headers of every request.
+type fakeS3 struct {
+	t         *testing.T
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+
+	mu           sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+	methods      []string
+	paths        []string
+	rejected     []string
+}
+
+func newFakeS3(t *testing.T, bucket, region, accessKey, secretKey string) *fakeS3 {
+	return &fakeS3{
+		t:            t,
+		bucket:       bucket,
+		region:       region,
+		accessKey:    accessKey,
+		secretKey:    secretKey,
+		objects:      map[string][]byte{},
+		contentTypes: map[string]string{},
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.methods = append(f.methods, r.Method)
+	f.paths = append(f.paths, r.URL.EscapedPath())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		f.t.Errorf("read request body: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if msg := f.verify(r, body); msg != "" {
+		f.rejected = append(f.rejected, r.Method+" "+r.URL.EscapedPath()+": "+msg)
+		http.Error(w, msg, http.StatusForbidden)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		f.t.Errorf("%s %s: path outside bucket %q", r.Method, r.URL.Path, f.bucket)
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = body
+		f.contentTypes[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify recomputes the signature of a request from what was received and
+// returns why it does not match, or "" when it does.
+func (f *fakeS3) verify(r *http.Request, body []byte) string {
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return "bad x-amz-date " + amzDate
+	}
+	if d := time.Since(signedAt); d < -time.Minute || d > time.Minute {
+		return "x-amz-date " + amzDate + " is not current"
+	}
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != sha256Hex(body) {
+		return "x-amz-content-sha256 does not match the body"
+	}
+
+	scope := amzDate[:8] + "/" + f.region + "/s3/aws4_request"
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		"host:" + r.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+	key := hmacSHA256([]byte("AWS4"+f.secretKey), amzDate[:8])
+	for _, part := range []string{f.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	want := "AWS4-HMAC-SHA256 Credential=" + f.accessKey + "/" + scope +
+		", SignedHeaders=" + signedHeaders +
+		", Signature=" + hex.EncodeToString(hmacSHA256(key, stringToSign))
+	if got := r.Header.Get("Authorization"); got != want {
+		return "authorization " + got + ", want " + want
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package images
+
+import (
+	"image"
+	"image/color"
+)
+
+// thumbnail scales img down to fit a size×size box, averaging the source
+// pixels behind each thumbnail pixel, and flattens transparency onto white.
+// Images that already fit keep their size.
+func thumbnail(img image.Image, size int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if longest := max(w, h); longest > size {
+		tw = max(1, w*size/longest)
+		th = max(1, h*size/longest)
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		sy0, sy1 := span(y, h, th)
+		for x := 0; x < tw; x++ {
+			sx0, sx1 := span(x, w, tw)
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := img.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// Colours are alpha-premultiplied, so white shows through
+			// in proportion to the missing alpha
+			white := 0xffff - a/n
+			thumb.Set(x, y, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(bl/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+	return thumb
+}
+
+// span returns the source pixels [from, to) behind thumbnail pixel i of n
+// along an edge of length src.
+func span(i, src, n int) (from, to int) {
+	from = i * src / n
+	to = (i + 1) * src / n
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
@@ -0,0 +1,79 @@
+package images
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThumbnailSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		wantW, wantH int
+	}{
+		{"landscape", 1024, 512, 256, 128},
+		{"portrait", 300, 600, 128, 256},
+		{"square", 512, 512, 256, 256},
+		{"fits", 100, 50, 100, 50},
+		{"thin", 5000, 2, 256, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(10, 20, 10+tt.w, 20+tt.h))
+			b := thumbnail(src, ThumbnailSize).Bounds()
+			if b.Min != (image.Point{}) || b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Errorf("thumbnail of %dx%d = %v, want %dx%d at the origin", tt.w, tt.h, b, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestThumbnailAveragesPixels(t *testing.T) {
+	// Black and white columns average to grey at half the width
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{A: 0xff}
+			if x%2 == 1 {
+				c = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	thumb := thumbnail(src, 2)
+	if b := thumb.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+		t.Fatalf("thumbnail bounds = %v, want 2x1", b)
+	}
+	for x := 0; x < 2; x++ {
+		if got := thumb.RGBAAt(x, 0); got != (color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}) {
+			t.Errorf("pixel %d = %v, want opaque grey", x, got)
+		}
+	}
+}
+
+func TestThumbnailFlattensTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.SetNRGBA(0, 0, color.NRGBA{})
+	src.SetNRGBA(1, 0, color.NRGBA{R: 0xff, A: 0xff})
+	thumb := thumbnail(src, ThumbnailSize)
+	if got := thumb.RGBAAt(0, 0); got != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+	if got := thumb.RGBAAt(1, 0); got != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("opaque pixel = %v, want red", got)
+	}
+}
+
+func TestThumbnailKey(t *testing.T) {
+	tests := map[string]string{
+		"products/a/b.png": "products/a/b_thumb.jpg",
+		"products/a/b.jpg": "products/a/b_thumb.jpg",
+		"products/a/b":     "products/a/b_thumb.jpg",
+	}
+	for key, want := range tests {
+		if got := ThumbnailKey(key); got != want {
+			t.Errorf("ThumbnailKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
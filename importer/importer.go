@@ -0,0 +1,439 @@
+// Package importer loads products and their opening stock into a branch
+// from CSV and XLSX files.
+//
+// The first row of a file holds column headers, mapped to product fields by
+// a Mapping. Every row is validated and errors are reported row by row
+// before anything is written; valid rows are then booked through the
+// purchase upsert, in one transaction or in batches, so stock movements
+// and cost layers follow as for any purchase.
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/purchasing"
+	"github.com/sushan531/auth-sqlc/store"
+	"github.com/sushan531/auth-sqlc/units"
+)
+
+// Format is an import file format.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// Field is a product field an import column maps to.
+type Field string
+
+const (
+	FieldProductName     Field = "product_name"
+	FieldUniqueName      Field = "unique_name"
+	FieldSellingPrice    Field = "selling_price"
+	FieldMeasurementUnit Field = "measurement_unit"
+	// FieldQuantity is the opening stock, zero when the column is absent.
+	FieldQuantity Field = "quantity"
+	// FieldUnitCost is the purchase price of one unit of opening stock,
+	// zero when the column is absent.
+	FieldUnitCost Field = "unit_cost"
+)
+
+// requiredFields must have a column in every file.
+var requiredFields = []Field{FieldProductName, FieldUniqueName, FieldSellingPrice, FieldMeasurementUnit}
+
+// fields lists every field in the order errors are reported.
+var fields = slices.Concat(requiredFields, []Field{FieldQuantity, FieldUnitCost})
+
+const (
+	// MaxFileBytes is the largest file accepted.
+	MaxFileBytes = 20 << 20
+	// MaxRows is the largest number of rows accepted, header excluded.
+	MaxRows = 10000
+	// maxPartBytes bounds each decompressed XML part of a workbook.
+	maxPartBytes = 200 << 20
+	// maxColumns is the last column of a spreadsheet, XFD.
+	maxColumns = 16384
+)
+
+var (
+	// ErrUnknownFormat is returned when importing a format other than csv or xlsx.
+	ErrUnknownFormat = errors.New("importer: unknown import format")
+	// ErrTooLarge is returned when a file exceeds MaxFileBytes.
+	ErrTooLarge = errors.New("importer: file too large")
+	// ErrTooManyRows is returned when a file has more than MaxRows rows.
+	ErrTooManyRows = errors.New("importer: file has too many rows")
+	// ErrEmptyFile is returned when a file has no header row.
+	ErrEmptyFile = errors.New("importer: file has no header row")
+	// ErrMissingColumn is returned when a required field has no column.
+	ErrMissingColumn = errors.New("importer: required column missing")
+	// ErrNothingToImport is returned when a file has no valid rows.
+	ErrNothingToImport = errors.New("importer: no valid rows to import")
+
+	// ErrRequired is reported for a row with an empty required value.
+	ErrRequired = errors.New("importer: value required")
+	// ErrInvalidDecimal is reported for a value that is not a decimal number.
+	ErrInvalidDecimal = errors.New("importer: not a decimal number")
+	// ErrNegative is reported for a negative price, cost or quantity.
+	ErrNegative = errors.New("importer: value must not be negative")
+	// ErrDuplicateRow is reported for a unique_name already used by an earlier row.
+	ErrDuplicateRow = errors.New("importer: unique_name appears on an earlier row")
+)
+
+// importerRoles may import into their branches.
+var importerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// Mapping maps fields to the header of the column holding them. Fields
+// left out are looked up under their own name. Headers match regardless of
+// case, surrounding space and spaces in place of underscores.
+type Mapping map[Field]string
+
+// Request describes an import into a branch. BatchSize is the number of
+// rows committed per transaction; zero commits every row in one.
+type Request struct {
+	OrganizationID uuid.UUID
+	BranchUuid     uuid.UUID
+	UserProfileID  uuid.UUID
+	Format         Format
+	Mapping        Mapping
+	BatchSize      int
+	Comments       string
+}
+
+// Row is a valid row of a file. Line is its line in the file, the header
+// being line 1.
+type Row struct {
+	Line            int             `json:"line"`
+	ProductName     string          `json:"product_name"`
+	UniqueName      string          `json:"unique_name"`
+	SellingPrice    decimal.Decimal `json:"selling_price"`
+	MeasurementUnit string          `json:"measurement_unit"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	UnitCost        decimal.Decimal `json:"unit_cost"`
+}
+
+// RowError is a problem with one value of a row.
+type RowError struct {
+	Line  int    `json:"line"`
+	Field Field  `json:"field"`
+	Value string `json:"value"`
+	Err   error  `json:"-"`
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d, %s %q: %v", e.Line, e.Field, e.Value, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// Preview is the outcome of validating a file: the rows an import would
+// commit and the errors of the rows it would skip.
+type Preview struct {
+	Rows   []Row      `json:"rows"`
+	Errors []RowError `json:"errors"`
+}
+
+// Result is the outcome of an import. Committed counts the rows written
+// before an error stopped the import.
+type Result struct {
+	Preview
+	Committed int                  `json:"committed"`
+	Purchases []generated.Purchase `json:"purchases"`
+}
+
+// Service imports products.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns an importer Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Preview validates a file for req without writing anything.
+func (s *Service) Preview(ctx context.Context, req Request, r io.Reader) (Preview, error) {
+	q := s.db.Queries()
+	if _, err := authorize(ctx, q, req); err != nil {
+		return Preview{}, err
+	}
+	return preview(ctx, q, req, r)
+}
+
+// Import validates a file and commits its valid rows as opening stock
+// purchases, without a supplier or payment. Each batch is one purchase
+// group; when a batch fails, the batches before it stay committed and are
+// counted in Result.Committed.
+func (s *Service) Import(ctx context.Context, req Request, r io.Reader) (Result, error) {
+	q := s.db.Queries()
+	actor, err := authorize(ctx, q, req)
+	if err != nil {
+		return Result{}, err
+	}
+	p, err := preview(ctx, q, req, r)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Preview: p}
+	if len(p.Rows) == 0 {
+		return result, ErrNothingToImport
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(p.Rows)
+	}
+	for start := 0; start < len(p.Rows); start += batchSize {
+		batch := p.Rows[start:min(start+batchSize, len(p.Rows))]
+		var purchases []generated.Purchase
+		err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+			var err error
+			purchases, err = purchasing.RecordPurchase(ctx, q, purchaseParams(req, actor, batch))
+			return err
+		})
+		if err != nil {
+			return result, fmt.Errorf("import lines %d-%d: %w", batch[0].Line, batch[len(batch)-1].Line, err)
+		}
+		result.Committed += len(batch)
+		result.Purchases = append(result.Purchases, purchases...)
+	}
+	return result, nil
+}
+
+// authorize checks the user may import into the branch of req.
+func authorize(ctx context.Context, q *generated.Queries, req Request) (access.Actor, error) {
+	actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+	if err != nil {
+		return actor, err
+	}
+	return actor, actor.RequireBranchRole(req.BranchUuid, importerRoles...)
+}
+
+// preview reads and validates a file, then checks its units against the
+// organization's catalog and the products the branch already has.
+func preview(ctx context.Context, q *generated.Queries, req Request, r io.Reader) (Preview, error) {
+	records, err := readRows(r, req.Format)
+	if err != nil {
+		return Preview{}, err
+	}
+	p, err := parse(records, req.Mapping)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	catalog, err := q.ListUnits(ctx, req.OrganizationID)
+	if err != nil {
+		return Preview{}, fmt.Errorf("list units: %w", err)
+	}
+	known := make(map[string]bool, len(catalog))
+	for _, u := range catalog {
+		known[u.Name] = true
+	}
+	names := make([]string, len(p.Rows))
+	lineUnits := make([]string, len(p.Rows))
+	for i, row := range p.Rows {
+		names[i], lineUnits[i] = row.UniqueName, row.MeasurementUnit
+	}
+	products, err := q.ListBranchProductUnits(ctx, generated.ListBranchProductUnitsParams{
+		BranchUuid:  req.BranchUuid,
+		UniqueNames: names,
+	})
+	if err != nil {
+		return Preview{}, fmt.Errorf("list branch products: %w", err)
+	}
+	existing := make(map[string]bool, len(products))
+	for _, product := range products {
+		existing[product.UniqueName] = true
+	}
+	unconvertible, err := q.ListUnconvertiblePurchaseUnits(ctx, generated.ListUnconvertiblePurchaseUnitsParams{
+		UniqueNames: names,
+		Units:       lineUnits,
+		BranchUuid:  req.BranchUuid,
+	})
+	if err != nil {
+		return Preview{}, fmt.Errorf("check purchase units: %w", err)
+	}
+	rejected := make(map[string]bool, len(unconvertible))
+	for _, u := range unconvertible {
+		rejected[u.UniqueName] = true
+	}
+
+	// Restocked products may use any unit that converts to theirs; new
+	// products must use a unit of the catalog.
+	valid := p.Rows[:0]
+	for _, row := range p.Rows {
+		switch {
+		case rejected[row.UniqueName]:
+			p.Errors = append(p.Errors, RowError{row.Line, FieldMeasurementUnit, row.MeasurementUnit, units.ErrNoConversion})
+		case !existing[row.UniqueName] && !known[row.MeasurementUnit]:
+			p.Errors = append(p.Errors, RowError{row.Line, FieldMeasurementUnit, row.MeasurementUnit, units.ErrUnknownUnit})
+		default:
+			valid = append(valid, row)
+		}
+	}
+	p.Rows = valid
+	sortErrors(p.Errors)
+	return p, nil
+}
+
+// parse maps the columns of records and validates every row on its own.
+func parse(records [][]string, mapping Mapping) (Preview, error) {
+	header := 0
+	for header < len(records) && blank(records[header]) {
+		header++
+	}
+	if header == len(records) {
+		return Preview{}, ErrEmptyFile
+	}
+	if len(records)-header-1 > MaxRows {
+		return Preview{}, ErrTooManyRows
+	}
+	columns := make(map[string]int, len(records[header]))
+	for i, h := range records[header] {
+		if _, dup := columns[normalize(h)]; !dup {
+			columns[normalize(h)] = i
+		}
+	}
+	index := make(map[Field]int, len(fields))
+	for _, f := range fields {
+		name := string(f)
+		if mapped, ok := mapping[f]; ok {
+			name = mapped
+		}
+		if i, ok := columns[normalize(name)]; ok {
+			index[f] = i
+		}
+	}
+	for _, f := range requiredFields {
+		if _, ok := index[f]; !ok {
+			return Preview{}, fmt.Errorf("%s: %w", f, ErrMissingColumn)
+		}
+	}
+
+	var p Preview
+	seen := make(map[string]int)
+	for n, record := range records[header+1:] {
+		if blank(record) {
+			continue
+		}
+		line := header + n + 2
+		value := func(f Field) string {
+			i, ok := index[f]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		var errs []RowError
+		text := func(f Field) string {
+			v := value(f)
+			if v == "" {
+				errs = append(errs, RowError{line, f, v, ErrRequired})
+			}
+			return v
+		}
+		number := func(f Field, required bool) decimal.Decimal {
+			v := value(f)
+			if v == "" {
+				if required {
+					errs = append(errs, RowError{line, f, v, ErrRequired})
+				}
+				return decimal.Zero
+			}
+			d, err := decimal.NewFromString(v)
+			if err != nil {
+				errs = append(errs, RowError{line, f, v, ErrInvalidDecimal})
+				return decimal.Zero
+			}
+			if d.IsNegative() {
+				errs = append(errs, RowError{line, f, v, ErrNegative})
+			}
+			return d
+		}
+
+		row := Row{
+			Line:            line,
+			ProductName:     text(FieldProductName),
+			UniqueName:      text(FieldUniqueName),
+			SellingPrice:    number(FieldSellingPrice, true),
+			MeasurementUnit: text(FieldMeasurementUnit),
+			Quantity:        number(FieldQuantity, false),
+			UnitCost:        number(FieldUnitCost, false),
+		}
+		if first, dup := seen[row.UniqueName]; dup && row.UniqueName != "" {
+			errs = append(errs, RowError{line, FieldUniqueName, row.UniqueName,
+				fmt.Errorf("line %d: %w", first, ErrDuplicateRow)})
+		} else if row.UniqueName != "" {
+			seen[row.UniqueName] = line
+		}
+		if len(errs) > 0 {
+			p.Errors = append(p.Errors, errs...)
+			continue
+		}
+		p.Rows = append(p.Rows, row)
+	}
+	return p, nil
+}
+
+// purchaseParams books a batch of rows as one purchase group.
+func purchaseParams(req Request, actor access.Actor, rows []Row) generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams {
+	comments := req.Comments
+	if comments == "" {
+		comments = "Opening stock import"
+	}
+	arg := generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams{
+		TotalCost:      decimal.Zero,
+		BranchUuid:     req.BranchUuid,
+		UserEmail:      actor.Email,
+		Comments:       sql.NullString{String: comments, Valid: true},
+		OrganizationID: req.OrganizationID,
+	}
+	for _, row := range rows {
+		arg.TotalCost = arg.TotalCost.Add(row.UnitCost.Mul(row.Quantity))
+		arg.Column9 = append(arg.Column9, row.ProductName)
+		arg.Column10 = append(arg.Column10, row.UniqueName)
+		arg.Column11 = append(arg.Column11, row.UnitCost)
+		arg.Column12 = append(arg.Column12, row.Quantity)
+		arg.Column13 = append(arg.Column13, row.SellingPrice)
+		arg.Column14 = append(arg.Column14, row.MeasurementUnit)
+	}
+	return arg
+}
+
+// sortErrors orders errors by line and then by field.
+func sortErrors(errs []RowError) {
+	order := make(map[Field]int, len(fields))
+	for i, f := range fields {
+		order[f] = i
+	}
+	slices.SortStableFunc(errs, func(a, b RowError) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return order[a.Field] - order[b.Field]
+	})
+}
+
+func normalize(header string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+}
+
+func blank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// readRows reads every row of a CSV file or of the first sheet of an XLSX
+// workbook as text.
+func readRows(r io.Reader, format Format) ([][]string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+	if len(data) > MaxFileBytes {
+		return nil, ErrTooLarge
+	}
+	switch format {
+	case FormatCSV:
+		return readCSV(data)
+	case FormatXLSX:
+		return readXLSX(data)
+	}
+	return nil, fmt.Errorf("%q: %w", format, ErrUnknownFormat)
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	// Spreadsheet programs often start CSV exports with a byte order mark
+	cr := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	return rows, nil
+}
+
+// Parts of an XLSX workbook, as far as they are needed to read cell text.
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	// xlsxText is plain text in t or rich text split into runs.
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxWorksheet struct {
+		Rows []struct {
+			Number int        `xml:"r,attr"`
+			Cells  []xlsxCell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	xlsxCell struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline xlsxText `xml:"is"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+// readXLSX reads the first sheet of a workbook. Rows and cells left out of
+// the sheet come back empty, so columns and line numbers match what the
+// user sees in a spreadsheet.
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx: %w", err)
+	}
+	var workbook xlsxWorkbook
+	if err := decodePart(zr, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, ErrEmptyFile
+	}
+	var rels xlsxRelationships
+	if err := decodePart(zr, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	sheetPath := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RelID {
+			sheetPath = rel.Target
+		}
+	}
+	if sheetPath == "" {
+		return nil, fmt.Errorf("read xlsx: first sheet has no part")
+	}
+	if strings.HasPrefix(sheetPath, "/") {
+		sheetPath = strings.TrimPrefix(sheetPath, "/")
+	} else {
+		sheetPath = path.Join("xl", sheetPath)
+	}
+
+	var shared xlsxSharedStrings
+	if err := decodePart(zr, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errMissingPart) {
+		return nil, err
+	}
+	var sheet xlsxWorksheet
+	if err := decodePart(zr, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		number := row.Number
+		if number < 0 {
+			return nil, fmt.Errorf("read xlsx: bad row number %d", number)
+		}
+		if number == 0 {
+			number = len(rows) + 1
+		}
+		if number > MaxRows+1 {
+			return nil, ErrTooManyRows
+		}
+		for len(rows) < number {
+			rows = append(rows, nil)
+		}
+		var cells []string
+		for _, c := range row.Cells {
+			col := len(cells)
+			if c.Ref != "" {
+				col, err = columnIndex(c.Ref)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col], err = cellText(c, shared.Items)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rows[number-1] = cells
+	}
+	return rows, nil
+}
+
+var errMissingPart = errors.New("importer: xlsx part missing")
+
+// decodePart decodes the XML part of a workbook at name into v.
+func decodePart(zr *zip.Reader, name string, v any) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("read xlsx %s: %w", name, errMissingPart)
+	}
+	defer f.Close()
+	if err := xml.NewDecoder(io.LimitReader(f, maxPartBytes)).Decode(v); err != nil {
+		return fmt.Errorf("read xlsx %s: %w", name, err)
+	}
+	return nil
+}
+
+// cellText returns the text of a cell. Numbers are written back in their
+// shortest form, so 0.1 stored as 0.10000000000000001 reads as 0.1.
+func cellText(c xlsxCell, shared []xlsxText) (string, error) {
+	switch c.Type {
+	case "s":
+		i, err := strconv.Atoi(c.Value)
+		if err != nil || i < 0 || i >= len(shared) {
+			return "", fmt.Errorf("read xlsx cell %s: bad shared string %q", c.Ref, c.Value)
+		}
+		return shared[i].String(), nil
+	case "inlineStr":
+		return c.Inline.String(), nil
+	case "b":
+		if c.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case "", "n":
+		if f, err := strconv.ParseFloat(c.Value, 64); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	}
+	return c.Value, nil
+}
+
+// columnIndex returns the zero-based column of a cell reference such as
+// "AB12".
+func columnIndex(ref string) (int, error) {
+	col := 0
+	i := 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A'+1)
+		// Stop before long references overflow
+		if col > maxColumns {
+			return 0, fmt.Errorf("read xlsx: bad cell reference %q", ref)
+		}
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("read xlsx: bad cell reference %q", ref)
+	}
+	return col - 1, nil
+}
@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// workbook builds an XLSX file whose first sheet holds sheetData, with
+// sharedStrings as its shared string table when it is not empty.
+func workbook(t *testing.T, sheetData, sharedStrings string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+			xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<sheetData>` + sheetData + `</sheetData></worksheet>`,
+	}
+	if sharedStrings != "" {
+		parts["xl/sharedStrings.xml"] = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			sharedStrings + `</sst>`
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	data := workbook(t, `
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+		<row r="3"><c r="A3" t="inlineStr"><is><t>Desk</t></is></c><c r="C3"><v>0.10000000000000001</v></c></row>
+		<row><c t="b"><v>1</v></c><c t="s"><v>2</v></c></row>`,
+		`<si><t>name</t></si><si><t>price</t></si><si><r><t>Rich </t></r><r><t>text</t></r></si>`)
+	rows, err := readXLSX(data)
+	if err != nil {
+		t.Fatalf("readXLSX: %v", err)
+	}
+	want := [][]string{
+		{"name", "price"},
+		nil,
+		{"Desk", "", "0.1"},
+		{"TRUE", "Rich text"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestReadXLSXRejectsBadSheets(t *testing.T) {
+	tests := []struct {
+		name      string
+		sheetData string
+		shared    string
+		want      string
+		wantErr   error
+	}{
+		{name: "negative row", sheetData: `<row r="-1"><c r="A1"><v>1</v></c></row>`, want: "bad row number"},
+		{name: "overflowing reference", sheetData: `<row r="1"><c r="ZZZZZZZZZZZZZZZZ1"><v>1</v></c></row>`, want: "bad cell reference"},
+		{name: "column past XFD", sheetData: `<row r="1"><c r="XFE1"><v>1</v></c></row>`, want: "bad cell reference"},
+		{name: "reference without column", sheetData: `<row r="1"><c r="12"><v>1</v></c></row>`, want: "bad cell reference"},
+		{name: "shared string out of range", sheetData: `<row r="1"><c r="A1" t="s"><v>3</v></c></row>`,
+			shared: `<si><t>only</t></si>`, want: "bad shared string"},
+		{name: "too many rows", sheetData: `<row r="10002"><c r="A10002"><v>1</v></c></row>`, wantErr: ErrTooManyRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readXLSX(workbook(t, tt.sheetData, tt.shared))
+			switch {
+			case err == nil:
+				t.Fatal("readXLSX succeeded, want an error")
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			case tt.want != "" && !strings.Contains(err.Error(), tt.want):
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := map[string]int{"A1": 0, "Z9": 25, "AA1": 26, "AB12": 27, "XFD1048576": maxColumns - 1}
+	for ref, want := range tests {
+		got, err := columnIndex(ref)
+		if err != nil || got != want {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", ref, got, err, want)
+		}
+	}
+	for _, ref := range []string{"", "1", "a1", "XFE1", strings.Repeat("Z", 40) + "1"} {
+		if _, err := columnIndex(ref); err == nil {
+			t.Errorf("columnIndex(%q) succeeded, want an error", ref)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	rows, err := readCSV([]byte("\ufeffname,price\nDesk,10\nChair\n"))
+	if err != nil {
+		t.Fatalf("readCSV: %v", err)
+	}
+	want := [][]string{{"name", "price"}, {"Desk", "10"}, {"Chair"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Package inventory maintains the stock movement ledger. Every change to a
+// product's quantity is an appended stock_movements row; the
+// products.remaining_quantity column is a projection kept up to date by a
+// database trigger and can be rebuilt from the ledger with Reconcile.
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// ErrZeroQuantity is returned when a movement would not change the stock.
+var ErrZeroQuantity = errors.New("inventory: movement quantity must not be zero")
+
+// Movement describes a single ledger entry. Quantity is signed: positive
+// values add stock to the product, negative values remove it.
+type Movement struct {
+	ProductID      uuid.UUID
+	BranchUuid     uuid.UUID
+	OrganizationID uuid.UUID
+	Type           generated.MovementType
+	Quantity       decimal.Decimal
+	ReferenceID    uuid.NullUUID
+	UserProfileID  uuid.NullUUID
+	Comments       string
+}
+
+// Service records stock movements and reconciles the quantity projection.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns an inventory Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// RecordMovement appends m to the ledger in its own transaction.
+func (s *Service) RecordMovement(ctx context.Context, m Movement) (generated.StockMovement, error) {
+	var movement generated.StockMovement
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		movement, err = RecordMovement(ctx, q, m)
+		return err
+	})
+	return movement, err
+}
+
+// RecordMovement appends m to the ledger using q, so callers can include the
+// movement in a transaction they already hold.
+func RecordMovement(ctx context.Context, q *generated.Queries, m Movement) (generated.StockMovement, error) {
+	if m.Quantity.IsZero() {
+		return generated.StockMovement{}, ErrZeroQuantity
+	}
+	movement, err := q.InsertStockMovement(ctx, generated.InsertStockMovementParams{
+		ProductID:      m.ProductID,
+		BranchUuid:     m.BranchUuid,
+		OrganizationID: m.OrganizationID,
+		MovementType:   m.Type,
+		Quantity:       m.Quantity,
+		ReferenceID:    m.ReferenceID,
+		UserProfileID:  m.UserProfileID,
+		Comments:       sql.NullString{String: m.Comments, Valid: m.Comments != ""},
+	})
+	if err != nil {
+		return generated.StockMovement{}, fmt.Errorf("insert %s movement for product %s: %w", m.Type, m.ProductID, err)
+	}
+	return movement, nil
+}
+
+// ProductLedger returns the movements of a product in order with the running
+// balance after each one.
+func (s *Service) ProductLedger(ctx context.Context, productID, organizationID uuid.UUID) ([]generated.ListProductStockMovementsRow, error) {
+	return s.db.Queries().ListProductStockMovements(ctx, generated.ListProductStockMovementsParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+}
+
+// Reconcile reports every product whose remaining_quantity differs from the
+// sum of its ledger movements. An invalid organizationID checks all
+// organizations. When fix is true the projection is recomputed from the
+// ledger for each mismatch in a single transaction.
+func (s *Service) Reconcile(ctx context.Context, organizationID uuid.NullUUID, fix bool) ([]generated.ListStockMismatchesRow, error) {
+	mismatches, err := s.db.Queries().ListStockMismatches(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("list stock mismatches: %w", err)
+	}
+	if !fix || len(mismatches) == 0 {
+		return mismatches, nil
+	}
+	err = s.db.WithTx(ctx, func(q *generated.Queries) error {
+		for _, m := range mismatches {
+			if _, err := q.ReconcileProductStock(ctx, m.ProductID); err != nil {
+				return fmt.Errorf("reconcile product %s: %w", m.ProductID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mismatches, nil
+}
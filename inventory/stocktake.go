@@ -0,0 +1,251 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/costing"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+var (
+	// ErrStocktakeNotFound is returned when a session does not exist in the organization.
+	ErrStocktakeNotFound = errors.New("inventory: stocktake session not found")
+	// ErrStocktakeClosed is returned when counting or posting a session that is no longer open.
+	ErrStocktakeClosed = errors.New("inventory: stocktake session is not open")
+)
+
+// stocktakeRoles may open, count, post and cancel stocktake sessions.
+var stocktakeRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// stocktakeReaderRoles may read the variances of stocktake sessions.
+var stocktakeReaderRoles = []access.Role{access.RoleAdmin, access.RoleAdminReadOnly, access.RoleBranchManager, access.RoleBranchReadOnly}
+
+// CountEntry is the physically counted quantity of a product.
+type CountEntry struct {
+	ProductID       uuid.UUID
+	CountedQuantity decimal.Decimal
+	Reason          string
+}
+
+// OpenStocktake starts a count session for a branch.
+func (s *Service) OpenStocktake(ctx context.Context, organizationID, branchUuid, userProfileID uuid.UUID, comments string) (generated.StocktakeSession, error) {
+	var session generated.StocktakeSession
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		if err := actor.RequireBranchRole(branchUuid, stocktakeRoles...); err != nil {
+			return err
+		}
+		session, err = q.InsertStocktakeSession(ctx, generated.InsertStocktakeSessionParams{
+			BranchUuid:     branchUuid,
+			OrganizationID: organizationID,
+			OpenedBy:       userProfileID,
+			Comments:       sql.NullString{String: comments, Valid: comments != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("insert stocktake session: %w", err)
+		}
+		return nil
+	})
+	return session, err
+}
+
+// RecordCounts saves counted quantities in an open session, together with
+// the remaining_quantity each product is expected to hold at the time.
+// Counting a product again replaces its previous count.
+func (s *Service) RecordCounts(ctx context.Context, sessionID, organizationID, userProfileID uuid.UUID, counts []CountEntry) ([]generated.StocktakeCount, error) {
+	var saved []generated.StocktakeCount
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, err := lockOpenStocktake(ctx, q, sessionID, organizationID, userProfileID); err != nil {
+			return err
+		}
+		for _, c := range counts {
+			if c.CountedQuantity.IsNegative() {
+				return fmt.Errorf("count for product %s: %w", c.ProductID, ErrZeroQuantity)
+			}
+			count, err := q.UpsertStocktakeCount(ctx, generated.UpsertStocktakeCountParams{
+				CountedQuantity: c.CountedQuantity,
+				Reason:          sql.NullString{String: c.Reason, Valid: c.Reason != ""},
+				CountedBy:       userProfileID,
+				SessionID:       sessionID,
+				ProductID:       c.ProductID,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("product %s: %w", c.ProductID, ErrProductNotInBranch)
+			}
+			if err != nil {
+				return fmt.Errorf("save count for product %s: %w", c.ProductID, err)
+			}
+			saved = append(saved, count)
+		}
+		return nil
+	})
+	return saved, err
+}
+
+// StocktakeVariances compares the counted quantities of a session with the
+// remaining_quantity each product had when it was counted. The user needs a
+// reading role in the session's branch.
+func (s *Service) StocktakeVariances(ctx context.Context, sessionID, organizationID, userProfileID uuid.UUID) ([]generated.ListStocktakeVariancesRow, error) {
+	q := s.db.Queries()
+	session, err := q.GetStocktakeSession(ctx, generated.GetStocktakeSessionParams{
+		SessionID:      sessionID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrStocktakeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get stocktake session: %w", err)
+	}
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := actor.RequireBranchRole(session.BranchUuid, stocktakeReaderRoles...); err != nil {
+		return nil, err
+	}
+	return q.ListStocktakeVariances(ctx, sessionID)
+}
+
+// PostStocktake closes a session and records an adjustment movement of the
+// reviewed variance for every counted product whose count differs from its
+// expected quantity. Stock moved since the count is kept. Products that were
+// not counted are left untouched.
+func (s *Service) PostStocktake(ctx context.Context, sessionID, organizationID, userProfileID uuid.UUID) (generated.StocktakeSession, []generated.LockStocktakeVariancesRow, error) {
+	var session generated.StocktakeSession
+	var adjusted []generated.LockStocktakeVariancesRow
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		session, err = lockOpenStocktake(ctx, q, sessionID, organizationID, userProfileID)
+		if err != nil {
+			return err
+		}
+
+		method, err := costing.MethodFor(ctx, q, organizationID)
+		if err != nil {
+			return err
+		}
+		variances, err := q.LockStocktakeVariances(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("list stocktake variances: %w", err)
+		}
+		for _, v := range variances {
+			if v.Variance.IsZero() {
+				continue
+			}
+			if v.Variance.IsNegative() {
+				_, err := costing.IssueStock(ctx, q, costing.Issue{
+					ProductID:   v.ProductID,
+					ReferenceID: sessionID,
+					Quantity:    v.Variance.Neg(),
+					Method:      method,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			comments := "stocktake"
+			if v.Reason.Valid {
+				comments = "stocktake: " + v.Reason.String
+			}
+			_, err := RecordMovement(ctx, q, Movement{
+				ProductID:      v.ProductID,
+				BranchUuid:     session.BranchUuid,
+				OrganizationID: organizationID,
+				Type:           generated.MovementTypeAdjustment,
+				Quantity:       v.Variance,
+				ReferenceID:    uuid.NullUUID{UUID: sessionID, Valid: true},
+				UserProfileID:  uuid.NullUUID{UUID: userProfileID, Valid: true},
+				Comments:       comments,
+			})
+			if err != nil {
+				return err
+			}
+			if v.Variance.IsPositive() {
+				if err := receiveAtAverageCost(ctx, q, session, v.ProductID, v.Variance); err != nil {
+					return err
+				}
+			}
+			adjusted = append(adjusted, v)
+		}
+
+		session, err = q.PostStocktakeSession(ctx, generated.PostStocktakeSessionParams{
+			SessionID: sessionID,
+			PostedBy:  uuid.NullUUID{UUID: userProfileID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("post stocktake session: %w", err)
+		}
+		return nil
+	})
+	return session, adjusted, err
+}
+
+// CancelStocktake discards an open session without adjusting any stock.
+func (s *Service) CancelStocktake(ctx context.Context, sessionID, organizationID, userProfileID uuid.UUID) (generated.StocktakeSession, error) {
+	var session generated.StocktakeSession
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, err := lockOpenStocktake(ctx, q, sessionID, organizationID, userProfileID); err != nil {
+			return err
+		}
+		var err error
+		session, err = q.CancelStocktakeSession(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("cancel stocktake session: %w", err)
+		}
+		return nil
+	})
+	return session, err
+}
+
+// receiveAtAverageCost adds a cost layer for stock found during a count,
+// valued at the product's current average cost.
+func receiveAtAverageCost(ctx context.Context, q *generated.Queries, session generated.StocktakeSession, productID uuid.UUID, quantity decimal.Decimal) error {
+	product, err := q.LockProductCost(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("lock product cost %s: %w", productID, err)
+	}
+	return costing.Receive(ctx, q, costing.Receipt{
+		ProductID:      productID,
+		BranchUuid:     session.BranchUuid,
+		OrganizationID: session.OrganizationID,
+		ReferenceID:    uuid.NullUUID{UUID: session.SessionID, Valid: true},
+		Quantity:       quantity,
+		UnitCost:       product.AverageCost,
+	})
+}
+
+// lockOpenStocktake locks an open session and checks the user may work on it.
+func lockOpenStocktake(ctx context.Context, q *generated.Queries, sessionID, organizationID, userProfileID uuid.UUID) (generated.StocktakeSession, error) {
+	session, err := q.LockStocktakeSession(ctx, generated.LockStocktakeSessionParams{
+		SessionID:      sessionID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrStocktakeNotFound
+	}
+	if err != nil {
+		return session, fmt.Errorf("lock stocktake session: %w", err)
+	}
+	if session.Status != generated.StocktakeStatusOpen {
+		return session, fmt.Errorf("session %s is %s: %w", sessionID, session.Status, ErrStocktakeClosed)
+	}
+
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return session, err
+	}
+	if err := actor.RequireBranchRole(session.BranchUuid, stocktakeRoles...); err != nil {
+		return session, err
+	}
+	return session, nil
+}
@@ -0,0 +1,438 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/costing"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+var (
+	// ErrTransferNotFound is returned when a transfer does not exist in the organization.
+	ErrTransferNotFound = errors.New("inventory: transfer not found")
+	// ErrTransferStatus is returned when a transfer is not in a status that allows the operation.
+	ErrTransferStatus = errors.New("inventory: transfer status does not allow this operation")
+	// ErrEmptyTransfer is returned when a transfer has no lines.
+	ErrEmptyTransfer = errors.New("inventory: transfer has no lines")
+	// ErrUnknownBranch is returned when a branch does not belong to the organization.
+	ErrUnknownBranch = errors.New("inventory: branch does not belong to the organization")
+	// ErrSameBranch is returned when a transfer's source and destination are the same branch.
+	ErrSameBranch = errors.New("inventory: transfer source and destination are the same branch")
+	// ErrProductNotInBranch is returned when a product is not stocked at the source branch.
+	ErrProductNotInBranch = errors.New("inventory: product does not belong to the source branch")
+	// ErrInsufficientStock is returned when the source branch does not hold enough stock.
+	ErrInsufficientStock = errors.New("inventory: insufficient stock")
+	// ErrUnknownTransferLine is returned when a received line is not part of the transfer.
+	ErrUnknownTransferLine = errors.New("inventory: line is not part of the transfer")
+	// ErrOverReceipt is returned when more is received than was dispatched on a line.
+	ErrOverReceipt = errors.New("inventory: received quantity exceeds dispatched quantity")
+)
+
+// TransferLine is a product and quantity to move between branches.
+type TransferLine struct {
+	ProductID uuid.UUID
+	Quantity  decimal.Decimal
+}
+
+// TransferRequest describes a new draft transfer.
+type TransferRequest struct {
+	SourceBranchUuid      uuid.UUID
+	DestinationBranchUuid uuid.UUID
+	OrganizationID        uuid.UUID
+	UserProfileID         uuid.UUID
+	Comments              string
+	Lines                 []TransferLine
+}
+
+// ReceivedLine is the quantity counted in at the destination for a transfer line.
+type ReceivedLine struct {
+	LineID   uuid.UUID
+	Quantity decimal.Decimal
+}
+
+// Transfer is a stock transfer with its lines.
+type Transfer struct {
+	generated.StockTransfer
+	Lines []generated.StockTransferLine `json:"lines"`
+}
+
+// Shortfall returns the dispatched quantity of a line that has not been received.
+func Shortfall(line generated.StockTransferLine) decimal.Decimal {
+	return line.Quantity.Sub(line.ReceivedQuantity)
+}
+
+// CreateTransfer saves a draft transfer. No stock moves until it is dispatched.
+func (s *Service) CreateTransfer(ctx context.Context, req TransferRequest) (Transfer, error) {
+	if len(req.Lines) == 0 {
+		return Transfer{}, ErrEmptyTransfer
+	}
+	if req.SourceBranchUuid == req.DestinationBranchUuid {
+		return Transfer{}, ErrSameBranch
+	}
+	var transfer Transfer
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		count, err := q.CountOrganizationBranches(ctx, generated.CountOrganizationBranchesParams{
+			OrganizationID: req.OrganizationID,
+			BranchIds:      []uuid.UUID{req.SourceBranchUuid, req.DestinationBranchUuid},
+		})
+		if err != nil {
+			return fmt.Errorf("check transfer branches: %w", err)
+		}
+		if count != 2 {
+			return ErrUnknownBranch
+		}
+
+		transfer.StockTransfer, err = q.InsertStockTransfer(ctx, generated.InsertStockTransferParams{
+			SourceBranchUuid:      req.SourceBranchUuid,
+			DestinationBranchUuid: req.DestinationBranchUuid,
+			OrganizationID:        req.OrganizationID,
+			CreatedBy:             req.UserProfileID,
+			Comments:              sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("insert transfer: %w", err)
+		}
+
+		for _, l := range req.Lines {
+			if !l.Quantity.IsPositive() {
+				return fmt.Errorf("transfer line for product %s: %w", l.ProductID, ErrZeroQuantity)
+			}
+			line, err := q.InsertStockTransferLine(ctx, generated.InsertStockTransferLineParams{
+				Quantity:   l.Quantity,
+				TransferID: transfer.TransferID,
+				ProductID:  l.ProductID,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("product %s: %w", l.ProductID, ErrProductNotInBranch)
+			}
+			if err != nil {
+				return fmt.Errorf("insert transfer line for product %s: %w", l.ProductID, err)
+			}
+			transfer.Lines = append(transfer.Lines, line)
+		}
+		return nil
+	})
+	return transfer, err
+}
+
+// GetTransfer returns a transfer and its lines.
+func (s *Service) GetTransfer(ctx context.Context, transferID, organizationID uuid.UUID) (Transfer, error) {
+	q := s.db.Queries()
+	transfer, err := q.GetStockTransfer(ctx, generated.GetStockTransferParams{
+		TransferID:     transferID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Transfer{}, ErrTransferNotFound
+	}
+	if err != nil {
+		return Transfer{}, fmt.Errorf("get transfer: %w", err)
+	}
+	lines, err := q.ListStockTransferLines(ctx, transferID)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("list transfer lines: %w", err)
+	}
+	return Transfer{StockTransfer: transfer, Lines: lines}, nil
+}
+
+// ListTransfers returns the transfers of an organization, newest first,
+// optionally filtered by status.
+func (s *Service) ListTransfers(ctx context.Context, organizationID uuid.UUID, status generated.NullTransferStatus) ([]generated.StockTransfer, error) {
+	return s.db.Queries().ListStockTransfers(ctx, generated.ListStockTransfersParams{
+		OrganizationID: organizationID,
+		Status:         status,
+	})
+}
+
+// DispatchTransfer takes the transfer quantities out of the source branch at
+// their current cost and marks the transfer as in transit.
+func (s *Service) DispatchTransfer(ctx context.Context, transferID, organizationID, userProfileID uuid.UUID) (Transfer, error) {
+	var transfer Transfer
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		transfer, err = lockTransfer(ctx, q, transferID, organizationID, generated.TransferStatusDraft)
+		if err != nil {
+			return err
+		}
+		method, err := costing.MethodFor(ctx, q, organizationID)
+		if err != nil {
+			return err
+		}
+
+		for i, line := range transfer.Lines {
+			product, err := q.LockTransferSourceProduct(ctx, line.SourceProductID)
+			if err != nil {
+				return fmt.Errorf("lock product %s: %w", line.SourceProductID, err)
+			}
+			if product.RemainingQuantity.LessThan(line.Quantity) {
+				return fmt.Errorf("%s has %s, transfer needs %s: %w",
+					product.UniqueName, product.RemainingQuantity, line.Quantity, ErrInsufficientStock)
+			}
+			cost, err := costing.IssueStock(ctx, q, costing.Issue{
+				ProductID:   line.SourceProductID,
+				ReferenceID: line.LineID,
+				Quantity:    line.Quantity,
+				Method:      method,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.SetStockTransferLineUnitCost(ctx, generated.SetStockTransferLineUnitCostParams{
+				LineID:   line.LineID,
+				UnitCost: cost.UnitCost,
+			})
+			if err != nil {
+				return fmt.Errorf("set unit cost for line %s: %w", line.LineID, err)
+			}
+			transfer.Lines[i].UnitCost = cost.UnitCost
+
+			_, err = RecordMovement(ctx, q, Movement{
+				ProductID:      line.SourceProductID,
+				BranchUuid:     transfer.SourceBranchUuid,
+				OrganizationID: organizationID,
+				Type:           generated.MovementTypeTransfer,
+				Quantity:       line.Quantity.Neg(),
+				ReferenceID:    uuid.NullUUID{UUID: transferID, Valid: true},
+				UserProfileID:  uuid.NullUUID{UUID: userProfileID, Valid: true},
+				Comments:       "transfer dispatched",
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		transfer.StockTransfer, err = q.DispatchStockTransfer(ctx, transferID)
+		if err != nil {
+			return fmt.Errorf("dispatch transfer: %w", err)
+		}
+		return nil
+	})
+	return transfer, err
+}
+
+// ReceiveTransfer books the received quantities into the destination branch
+// at the cost they were dispatched at, creating the destination product from the source product when the branch
+// does not stock it yet. Receiving can happen over several calls; the
+// transfer is completed once every line is fully received, or immediately
+// when closeShipment is true, in which case any shortfall is written off as
+// lost in transit: it is booked back into the source branch and removed again
+// by an adjustment, so the ledger shows the loss. Its cost stays issued.
+func (s *Service) ReceiveTransfer(ctx context.Context, transferID, organizationID, userProfileID uuid.UUID, received []ReceivedLine, closeShipment bool) (Transfer, error) {
+	var transfer Transfer
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		transfer, err = lockTransfer(ctx, q, transferID, organizationID, generated.TransferStatusDispatched)
+		if err != nil {
+			return err
+		}
+
+		lines := make(map[uuid.UUID]generated.StockTransferLine, len(transfer.Lines))
+		for _, line := range transfer.Lines {
+			lines[line.LineID] = line
+		}
+
+		for _, r := range received {
+			line, ok := lines[r.LineID]
+			if !ok {
+				return fmt.Errorf("line %s: %w", r.LineID, ErrUnknownTransferLine)
+			}
+			if r.Quantity.IsZero() {
+				continue
+			}
+			if r.Quantity.IsNegative() {
+				return fmt.Errorf("line %s: %w", r.LineID, ErrZeroQuantity)
+			}
+
+			if !line.DestinationProductID.Valid {
+				productID, err := q.UpsertTransferDestinationProduct(ctx, generated.UpsertTransferDestinationProductParams{
+					DestinationBranchUuid: transfer.DestinationBranchUuid,
+					SourceProductID:       line.SourceProductID,
+				})
+				if err != nil {
+					return fmt.Errorf("create destination product for %s: %w", line.SourceProductID, err)
+				}
+				line.DestinationProductID = uuid.NullUUID{UUID: productID, Valid: true}
+				err = q.SetStockTransferLineDestination(ctx, generated.SetStockTransferLineDestinationParams{
+					LineID:               line.LineID,
+					DestinationProductID: line.DestinationProductID,
+				})
+				if err != nil {
+					return fmt.Errorf("set destination product for line %s: %w", line.LineID, err)
+				}
+			}
+
+			updated, err := q.AddStockTransferLineReceivedQuantity(ctx, generated.AddStockTransferLineReceivedQuantityParams{
+				Quantity:   r.Quantity,
+				LineID:     line.LineID,
+				TransferID: transferID,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("line %s: %w", line.LineID, ErrOverReceipt)
+			}
+			if err != nil {
+				return fmt.Errorf("update received quantity for line %s: %w", line.LineID, err)
+			}
+			updated.DestinationProductID = line.DestinationProductID
+			lines[line.LineID] = updated
+
+			_, err = RecordMovement(ctx, q, Movement{
+				ProductID:      line.DestinationProductID.UUID,
+				BranchUuid:     transfer.DestinationBranchUuid,
+				OrganizationID: organizationID,
+				Type:           generated.MovementTypeTransfer,
+				Quantity:       r.Quantity,
+				ReferenceID:    uuid.NullUUID{UUID: transferID, Valid: true},
+				UserProfileID:  uuid.NullUUID{UUID: userProfileID, Valid: true},
+				Comments:       "transfer received",
+			})
+			if err != nil {
+				return err
+			}
+			err = costing.Receive(ctx, q, costing.Receipt{
+				ProductID:      line.DestinationProductID.UUID,
+				BranchUuid:     transfer.DestinationBranchUuid,
+				OrganizationID: organizationID,
+				ReferenceID:    uuid.NullUUID{UUID: line.LineID, Valid: true},
+				Quantity:       r.Quantity,
+				UnitCost:       line.UnitCost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		complete := true
+		for i, line := range transfer.Lines {
+			transfer.Lines[i] = lines[line.LineID]
+			if Shortfall(transfer.Lines[i]).IsPositive() {
+				complete = false
+			}
+		}
+		if !complete && closeShipment {
+			for _, line := range transfer.Lines {
+				if err := writeOffShortfall(ctx, q, transfer.StockTransfer, line, userProfileID); err != nil {
+					return err
+				}
+			}
+		}
+		if complete || closeShipment {
+			transfer.StockTransfer, err = q.CompleteStockTransfer(ctx, transferID)
+			if err != nil {
+				return fmt.Errorf("complete transfer: %w", err)
+			}
+		}
+		return nil
+	})
+	return transfer, err
+}
+
+// writeOffShortfall records the quantity of a line that never arrived as
+// returned to the source branch and lost there.
+func writeOffShortfall(ctx context.Context, q *generated.Queries, transfer generated.StockTransfer, line generated.StockTransferLine, userProfileID uuid.UUID) error {
+	shortfall := Shortfall(line)
+	if !shortfall.IsPositive() {
+		return nil
+	}
+	for _, m := range []Movement{
+		{Type: generated.MovementTypeTransfer, Quantity: shortfall, Comments: "transfer shortfall"},
+		{Type: generated.MovementTypeAdjustment, Quantity: shortfall.Neg(), Comments: "lost in transit"},
+	} {
+		m.ProductID = line.SourceProductID
+		m.BranchUuid = transfer.SourceBranchUuid
+		m.OrganizationID = transfer.OrganizationID
+		m.ReferenceID = uuid.NullUUID{UUID: transfer.TransferID, Valid: true}
+		m.UserProfileID = uuid.NullUUID{UUID: userProfileID, Valid: true}
+		if _, err := RecordMovement(ctx, q, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CancelTransfer cancels a draft transfer, or a dispatched transfer that has
+// not been received at all, in which case the stock returns to the source
+// branch.
+func (s *Service) CancelTransfer(ctx context.Context, transferID, organizationID, userProfileID uuid.UUID) (Transfer, error) {
+	var transfer Transfer
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		transfer, err = lockTransfer(ctx, q, transferID, organizationID,
+			generated.TransferStatusDraft, generated.TransferStatusDispatched)
+		if err != nil {
+			return err
+		}
+
+		if transfer.Status == generated.TransferStatusDispatched {
+			for _, line := range transfer.Lines {
+				if !line.ReceivedQuantity.IsZero() {
+					return fmt.Errorf("transfer %s is partially received, close the shipment instead: %w",
+						transferID, ErrTransferStatus)
+				}
+			}
+			for _, line := range transfer.Lines {
+				_, err := RecordMovement(ctx, q, Movement{
+					ProductID:      line.SourceProductID,
+					BranchUuid:     transfer.SourceBranchUuid,
+					OrganizationID: organizationID,
+					Type:           generated.MovementTypeTransfer,
+					Quantity:       line.Quantity,
+					ReferenceID:    uuid.NullUUID{UUID: transferID, Valid: true},
+					UserProfileID:  uuid.NullUUID{UUID: userProfileID, Valid: true},
+					Comments:       "transfer cancelled",
+				})
+				if err != nil {
+					return err
+				}
+				err = costing.Receive(ctx, q, costing.Receipt{
+					ProductID:      line.SourceProductID,
+					BranchUuid:     transfer.SourceBranchUuid,
+					OrganizationID: organizationID,
+					ReferenceID:    uuid.NullUUID{UUID: line.LineID, Valid: true},
+					Quantity:       line.Quantity,
+					UnitCost:       line.UnitCost,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		transfer.StockTransfer, err = q.CancelStockTransfer(ctx, transferID)
+		if err != nil {
+			return fmt.Errorf("cancel transfer: %w", err)
+		}
+		return nil
+	})
+	return transfer, err
+}
+
+// lockTransfer loads a transfer for update and checks it is in one of the
+// allowed statuses.
+func lockTransfer(ctx context.Context, q *generated.Queries, transferID, organizationID uuid.UUID, allowed ...generated.TransferStatus) (Transfer, error) {
+	transfer, err := q.LockStockTransfer(ctx, generated.LockStockTransferParams{
+		TransferID:     transferID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Transfer{}, ErrTransferNotFound
+	}
+	if err != nil {
+		return Transfer{}, fmt.Errorf("lock transfer: %w", err)
+	}
+
+	if !slices.Contains(allowed, transfer.Status) {
+		return Transfer{}, fmt.Errorf("transfer %s is %s: %w", transferID, transfer.Status, ErrTransferStatus)
+	}
+
+	lines, err := q.ListStockTransferLines(ctx, transferID)
+	if err != nil {
+		return Transfer{}, fmt.Errorf("list transfer lines: %w", err)
+	}
+	return Transfer{StockTransfer: transfer, Lines: lines}, nil
+}
@@ -380,7 +380,7 @@ func testOrganizationOperations(ctx context.Context, queries *generated.Queries)
 //		Column12: []decimal.Decimal{decimal.NewFromFloat(2),
 //			decimal.NewFromFloat(6)}, // units (will be added)
 //		Column13: []decimal.Decimal{decimal.NewFromFloat(420.00),
-//			decimal.NewFromFloat(28.00)}, // selling_price (updated)
+//			decimal.NewFromFloat(28.00)}, // selling_price (ignored on restock)
 //		Column14: []string{"pieces", "pieces"}, // measurement_unit
 //	}
 //
@@ -407,6 +407,6 @@ func testOrganizationOperations(ctx context.Context, queries *generated.Queries)
 //	// Show the difference in behavior
 //	fmt.Println("\n📊 Summary of INSERT vs UPDATE behavior:")
 //	fmt.Println("   INSERT: Creates new products with initial quantities")
-//	fmt.Println("   UPDATE: Adds to existing product quantities and updates selling prices")
+//	fmt.Println("   UPDATE: Adds to existing product quantities and keeps selling prices")
 //	fmt.Printf("   Total products created: %d (same unique_name triggers update, not duplicate)\n", len(finalProductNames))
 //}
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS stock_movements_append_only ON stock_movements;
+DROP TRIGGER IF EXISTS stock_movements_apply ON stock_movements;
+DROP FUNCTION IF EXISTS reject_stock_movement_change;
+DROP FUNCTION IF EXISTS apply_stock_movement;
+DROP TABLE IF EXISTS stock_movements;
+DROP INDEX IF EXISTS products_branch_unique_name_idx;
+DROP TYPE IF EXISTS movement_type;
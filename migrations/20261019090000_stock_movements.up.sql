@@ -0,0 +1,65 @@
+-- Stock movement types recorded in the ledger
+CREATE TYPE movement_type AS ENUM ('purchase', 'sale', 'return', 'adjustment', 'transfer');
+
+-- Products are identified by unique_name within a branch
+CREATE UNIQUE INDEX IF NOT EXISTS products_branch_unique_name_idx
+    ON products (branch_uuid, unique_name);
+
+-- Create Stock Movements Table (append-only ledger)
+CREATE TABLE IF NOT EXISTS stock_movements
+(
+    movement_id     uuid DEFAULT uuidv7() PRIMARY KEY,
+    product_id      uuid          NOT NULL,
+    branch_uuid     uuid          NOT NULL,
+    organization_id uuid          NOT NULL,
+    movement_type   movement_type NOT NULL,
+    quantity        NUMERIC       NOT NULL,
+    reference_id    uuid,
+    user_profile_id uuid,
+    comments        TEXT,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    FOREIGN KEY (product_id) REFERENCES products (product_id),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (user_profile_id) REFERENCES user_profile (id)
+    );
+
+CREATE INDEX IF NOT EXISTS stock_movements_product_idx
+    ON stock_movements (product_id, created_at);
+
+-- Seed the ledger with the quantities on hand before it existed
+INSERT INTO stock_movements (product_id, branch_uuid, organization_id, movement_type, quantity, comments)
+SELECT product_id, branch_uuid, organization_id, 'adjustment', remaining_quantity, 'opening balance'
+FROM products
+WHERE remaining_quantity <> 0;
+
+-- Keep products.remaining_quantity in step with the ledger
+CREATE OR REPLACE FUNCTION apply_stock_movement() RETURNS TRIGGER AS
+$$
+BEGIN
+    UPDATE products
+    SET remaining_quantity = remaining_quantity + NEW.quantity
+    WHERE product_id = NEW.product_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_apply
+    AFTER INSERT
+    ON stock_movements
+    FOR EACH ROW
+EXECUTE FUNCTION apply_stock_movement();
+
+-- Ledger rows are never edited; corrections are new movements
+CREATE OR REPLACE FUNCTION reject_stock_movement_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_append_only
+    BEFORE UPDATE OR DELETE
+    ON stock_movements
+    FOR EACH ROW
+EXECUTE FUNCTION reject_stock_movement_change();
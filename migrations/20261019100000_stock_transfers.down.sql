@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_transfer_lines;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TYPE IF EXISTS transfer_status;
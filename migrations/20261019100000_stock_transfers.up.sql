@@ -0,0 +1,37 @@
+CREATE TYPE transfer_status AS ENUM ('draft', 'dispatched', 'received', 'cancelled');
+
+-- Create Stock Transfers Table
+CREATE TABLE IF NOT EXISTS stock_transfers
+(
+    transfer_id             uuid DEFAULT uuidv7() PRIMARY KEY,
+    source_branch_uuid      uuid            NOT NULL,
+    destination_branch_uuid uuid            NOT NULL,
+    organization_id         uuid            NOT NULL,
+    status                  transfer_status NOT NULL DEFAULT 'draft',
+    created_by              uuid            NOT NULL,
+    comments                TEXT,
+    created_at              TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    dispatched_at           TIMESTAMPTZ,
+    received_at             TIMESTAMPTZ,
+    FOREIGN KEY (source_branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (destination_branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (created_by) REFERENCES user_profile (id),
+    CHECK (source_branch_uuid <> destination_branch_uuid)
+    );
+
+-- Create Stock Transfer Lines Table
+CREATE TABLE IF NOT EXISTS stock_transfer_lines
+(
+    line_id                uuid DEFAULT uuidv7() PRIMARY KEY,
+    transfer_id            uuid    NOT NULL,
+    source_product_id      uuid    NOT NULL,
+    destination_product_id uuid,
+    quantity               NUMERIC NOT NULL CHECK (quantity > 0),
+    received_quantity      NUMERIC NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    FOREIGN KEY (transfer_id) REFERENCES stock_transfers (transfer_id) ON DELETE CASCADE,
+    FOREIGN KEY (source_product_id) REFERENCES products (product_id),
+    FOREIGN KEY (destination_product_id) REFERENCES products (product_id),
+    UNIQUE (transfer_id, source_product_id),
+    CHECK (received_quantity <= quantity)
+    );
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stocktake_counts;
+DROP TABLE IF EXISTS stocktake_sessions;
+DROP TYPE IF EXISTS stocktake_status;
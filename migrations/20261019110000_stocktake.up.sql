@@ -0,0 +1,35 @@
+CREATE TYPE stocktake_status AS ENUM ('open', 'posted', 'cancelled');
+
+-- Create Stocktake Sessions Table
+CREATE TABLE IF NOT EXISTS stocktake_sessions
+(
+    session_id      uuid DEFAULT uuidv7() PRIMARY KEY,
+    branch_uuid     uuid             NOT NULL,
+    organization_id uuid             NOT NULL,
+    status          stocktake_status NOT NULL DEFAULT 'open',
+    opened_by       uuid             NOT NULL,
+    opened_at       TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    posted_by       uuid,
+    posted_at       TIMESTAMPTZ,
+    comments        TEXT,
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (opened_by) REFERENCES user_profile (id),
+    FOREIGN KEY (posted_by) REFERENCES user_profile (id)
+    );
+
+-- Create Stocktake Counts Table
+CREATE TABLE IF NOT EXISTS stocktake_counts
+(
+    count_id         uuid DEFAULT uuidv7() PRIMARY KEY,
+    session_id       uuid        NOT NULL,
+    product_id       uuid        NOT NULL,
+    counted_quantity NUMERIC     NOT NULL CHECK (counted_quantity >= 0),
+    reason           TEXT,
+    counted_by       uuid        NOT NULL,
+    counted_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (session_id) REFERENCES stocktake_sessions (session_id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products (product_id),
+    FOREIGN KEY (counted_by) REFERENCES user_profile (id),
+    UNIQUE (session_id, product_id)
+    );
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS cost_allocations;
+DROP TABLE IF EXISTS cost_layers;
+ALTER TABLE stock_transfer_lines DROP COLUMN IF EXISTS unit_cost;
+ALTER TABLE products DROP COLUMN IF EXISTS average_cost;
+ALTER TABLE organization DROP COLUMN IF EXISTS costing_method;
+DROP TYPE IF EXISTS costing_method;
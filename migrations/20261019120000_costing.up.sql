@@ -0,0 +1,64 @@
+CREATE TYPE costing_method AS ENUM ('weighted_average', 'fifo');
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS costing_method costing_method NOT NULL DEFAULT 'weighted_average';
+
+-- Moving weighted average cost of the quantity on hand
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS average_cost NUMERIC NOT NULL DEFAULT 0;
+
+-- Unit cost each transferred line left the source branch at
+ALTER TABLE stock_transfer_lines
+    ADD COLUMN IF NOT EXISTS unit_cost NUMERIC NOT NULL DEFAULT 0;
+
+-- Create Cost Layers Table (one layer per receipt, consumed oldest first)
+CREATE TABLE IF NOT EXISTS cost_layers
+(
+    layer_id           uuid DEFAULT uuidv7() PRIMARY KEY,
+    product_id         uuid        NOT NULL,
+    branch_uuid        uuid        NOT NULL,
+    organization_id    uuid        NOT NULL,
+    reference_id       uuid,
+    unit_cost          NUMERIC     NOT NULL CHECK (unit_cost >= 0),
+    quantity           NUMERIC     NOT NULL CHECK (quantity > 0),
+    remaining_quantity NUMERIC     NOT NULL CHECK (remaining_quantity >= 0),
+    received_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (product_id) REFERENCES products (product_id),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    CHECK (remaining_quantity <= quantity)
+    );
+
+CREATE INDEX IF NOT EXISTS cost_layers_open_idx
+    ON cost_layers (product_id, received_at)
+    WHERE remaining_quantity > 0;
+
+-- Create Cost Allocations Table (layers consumed by each issue of stock)
+CREATE TABLE IF NOT EXISTS cost_allocations
+(
+    allocation_id uuid DEFAULT uuidv7() PRIMARY KEY,
+    layer_id      uuid        NOT NULL,
+    reference_id  uuid        NOT NULL,
+    quantity      NUMERIC     NOT NULL CHECK (quantity > 0),
+    unit_cost     NUMERIC     NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (layer_id) REFERENCES cost_layers (layer_id)
+    );
+
+CREATE INDEX IF NOT EXISTS cost_allocations_reference_idx
+    ON cost_allocations (reference_id);
+
+-- Seed costs from the purchase history of each product
+UPDATE products p
+SET average_cost = h.average_cost
+FROM (SELECT product_id, SUM(units * unit_purchase_price) / SUM(units) AS average_cost
+      FROM purchases
+      WHERE product_id IS NOT NULL
+      GROUP BY product_id
+      HAVING SUM(units) > 0) h
+WHERE h.product_id = p.product_id;
+
+INSERT INTO cost_layers (product_id, branch_uuid, organization_id, unit_cost, quantity, remaining_quantity)
+SELECT product_id, branch_uuid, organization_id, average_cost, remaining_quantity, remaining_quantity
+FROM products
+WHERE remaining_quantity > 0;
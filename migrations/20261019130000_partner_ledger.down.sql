@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS purchase_group_partner_idx;
+DROP INDEX IF EXISTS partner_payment_receipt_partner_idx;
+ALTER TABLE partner_payment_receipt DROP COLUMN IF EXISTS recorded_at;
+DROP INDEX IF EXISTS partners_organization_unique_name_idx;
@@ -0,0 +1,14 @@
+-- Partners are identified by unique_name within an organization
+CREATE UNIQUE INDEX IF NOT EXISTS partners_organization_unique_name_idx
+    ON partners (organization_id, unique_name);
+
+-- Date each payment or receipt was recorded, for partner statements
+ALTER TABLE partner_payment_receipt
+    ADD COLUMN IF NOT EXISTS recorded_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS partner_payment_receipt_partner_idx
+    ON partner_payment_receipt (partner_id, recorded_at);
+
+CREATE INDEX IF NOT EXISTS purchase_group_partner_idx
+    ON purchase_group (partner_id, purchase_date)
+    WHERE partner_id IS NOT NULL;
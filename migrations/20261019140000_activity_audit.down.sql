@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS activity_organization_time_idx;
+DROP TRIGGER IF EXISTS auth_activity ON auth;
+
+DO
+$$
+    DECLARE
+        audited TEXT;
+    BEGIN
+        FOREACH audited IN ARRAY ARRAY ['organization', 'branches', 'user_profile', 'user_organization_branch',
+            'products', 'purchase_group', 'purchases', 'sales_group', 'sales', 'partners', 'partner_payment_receipt',
+            'stock_movements', 'stock_transfers', 'stock_transfer_lines', 'stocktake_sessions', 'stocktake_counts',
+            'cost_layers', 'cost_allocations']
+            LOOP
+                EXECUTE format('DROP TRIGGER IF EXISTS %I ON %I', audited || '_activity', audited);
+            END LOOP;
+    END
+$$;
+
+DROP FUNCTION IF EXISTS record_activity;
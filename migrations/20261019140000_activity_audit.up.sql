@@ -0,0 +1,118 @@
+-- Record every change made by an identified user as an activity row.
+--
+-- The library tags each mutating statement with transaction-local settings:
+--   audit.identity         email of the acting user
+--   audit.organization_id  organization the user acts in
+--   audit.query            sqlc query name of the statement
+-- Changes made without audit.identity (migrations, maintenance scripts) are
+-- not recorded. TG_ARGV[0] names the key column of the table; any further
+-- arguments name columns whose values must never be copied into activity.
+CREATE OR REPLACE FUNCTION record_activity() RETURNS TRIGGER AS
+$$
+DECLARE
+    acting_identity TEXT := current_setting('audit.identity', true);
+    organization    uuid;
+    old_row         JSONB;
+    new_row         JSONB;
+    operation       operation_type;
+    secret          TEXT;
+BEGIN
+    IF acting_identity IS NULL OR acting_identity = '' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+    IF old_row = new_row THEN
+        RETURN NULL;
+    END IF;
+
+    FOR i IN 1 .. TG_NARGS - 1
+        LOOP
+            secret := TG_ARGV[i];
+            IF old_row -> secret IS DISTINCT FROM new_row -> secret AND old_row IS NOT NULL AND new_row IS NOT NULL THEN
+                new_row := jsonb_set(new_row, ARRAY [secret], '"[redacted, changed]"');
+            ELSE
+                new_row := jsonb_set(new_row, ARRAY [secret], '"[redacted]"');
+            END IF;
+            old_row := jsonb_set(old_row, ARRAY [secret], '"[redacted]"');
+        END LOOP;
+
+    organization := coalesce(
+            nullif(current_setting('audit.organization_id', true), ''),
+            coalesce(new_row, old_row) ->> 'organization_id'
+        )::uuid;
+    IF organization IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    operation := CASE TG_OP WHEN 'INSERT' THEN 'write' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END;
+
+    INSERT INTO activity (identity, operation, resource, old_value, new_value, status, time, organization_id)
+    VALUES (acting_identity,
+            operation,
+            ARRAY [TG_TABLE_NAME, coalesce(new_row, old_row) ->> TG_ARGV[0],
+                coalesce(current_setting('audit.query', true), '')],
+            ARRAY(SELECT format('%s=%s', o.key, o.value)
+                  FROM jsonb_each_text(old_row) o
+                  WHERE new_row IS NULL
+                     OR new_row -> o.key IS DISTINCT FROM old_row -> o.key
+                  ORDER BY o.key),
+            ARRAY(SELECT format('%s=%s', n.key, n.value)
+                  FROM jsonb_each_text(new_row) n
+                  WHERE old_row IS NULL
+                     OR old_row -> n.key IS DISTINCT FROM new_row -> n.key
+                  ORDER BY n.key),
+            true,
+            now(),
+            organization);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DO
+$$
+    DECLARE
+        audited RECORD;
+    BEGIN
+        FOR audited IN
+            SELECT *
+            FROM (VALUES ('organization', 'id'),
+                         ('branches', 'id'),
+                         ('user_profile', 'id'),
+                         ('user_organization_branch', 'id'),
+                         ('products', 'product_id'),
+                         ('purchase_group', 'purchase_group_id'),
+                         ('purchases', 'purchase_id'),
+                         ('sales_group', 'sales_group_id'),
+                         ('sales', 'sales_id'),
+                         ('partners', 'partner_id'),
+                         ('partner_payment_receipt', 'pr_id'),
+                         ('stock_movements', 'movement_id'),
+                         ('stock_transfers', 'transfer_id'),
+                         ('stock_transfer_lines', 'line_id'),
+                         ('stocktake_sessions', 'session_id'),
+                         ('stocktake_counts', 'count_id'),
+                         ('cost_layers', 'layer_id'),
+                         ('cost_allocations', 'allocation_id')) AS t (table_name, key_column)
+            LOOP
+                EXECUTE format(
+                        'CREATE TRIGGER %I AFTER INSERT OR UPDATE OR DELETE ON %I FOR EACH ROW EXECUTE FUNCTION record_activity(%L)',
+                        audited.table_name || '_activity', audited.table_name, audited.key_column);
+            END LOOP;
+    END
+$$;
+
+-- Credentials and key material are recorded as redacted
+CREATE TRIGGER auth_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON auth
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('id', 'password', 'keyset_data', 'encryption_key');
+
+CREATE INDEX IF NOT EXISTS activity_organization_time_idx
+    ON activity (organization_id, time);
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS activity_append_only ON activity;
+DROP TRIGGER IF EXISTS activity_chain ON activity;
+DROP FUNCTION IF EXISTS reject_activity_change;
+DROP FUNCTION IF EXISTS chain_activity;
+DROP INDEX IF EXISTS activity_organization_seq_idx;
+DROP FUNCTION IF EXISTS activity_row_hash;
+DROP FUNCTION IF EXISTS activity_array;
+DROP FUNCTION IF EXISTS activity_field;
+ALTER TABLE activity
+    DROP COLUMN IF EXISTS row_hash,
+    DROP COLUMN IF EXISTS prev_hash,
+    DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,118 @@
+-- Chain activity rows per organization: each row stores its position, the
+-- hash of the previous row and a SHA-256 hash over its own content and that
+-- previous hash, so editing or removing any row breaks every later link.
+ALTER TABLE activity
+    ADD COLUMN IF NOT EXISTS seq       BIGINT,
+    ADD COLUMN IF NOT EXISTS prev_hash TEXT,
+    ADD COLUMN IF NOT EXISTS row_hash  TEXT;
+
+-- Length-prefixed encoding of a value; NULL is encoded as '-1:'
+CREATE OR REPLACE FUNCTION activity_field(value TEXT) RETURNS TEXT AS
+$$
+SELECT CASE WHEN value IS NULL THEN '-1:' ELSE octet_length(value) || ':' || value END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Element count followed by each encoded element; NULL is encoded as '-1:'
+CREATE OR REPLACE FUNCTION activity_array(value TEXT[]) RETURNS TEXT AS
+$$
+SELECT CASE
+           WHEN value IS NULL THEN '-1:'
+           ELSE cardinality(value) || ':' ||
+                coalesce((SELECT string_agg(activity_field(e.element), '' ORDER BY e.position)
+                          FROM unnest(value) WITH ORDINALITY AS e (element, position)), '')
+           END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Hash of an activity row. The verifier recomputes it independently, so the
+-- encoding must not change.
+CREATE OR REPLACE FUNCTION activity_row_hash(a activity) RETURNS TEXT AS
+$$
+SELECT encode(sha256(convert_to(
+                         activity_field(a.seq::text) ||
+                         activity_field(a.prev_hash) ||
+                         activity_field(a.id::text) ||
+                         activity_field(a.identity) ||
+                         activity_field(a.operation::text) ||
+                         activity_array(a.resource) ||
+                         activity_array(a.old_value) ||
+                         activity_array(a.new_value) ||
+                         activity_field(CASE WHEN a.status THEN 't' ELSE 'f' END) ||
+                         activity_field(to_char(a.time AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')) ||
+                         activity_field(a.organization_id::text),
+                         'UTF8')), 'hex');
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Chain the rows recorded before the chain existed, oldest first
+DO
+$$
+    DECLARE
+        entry        RECORD;
+        organization uuid;
+        next_seq     BIGINT;
+        last_hash    TEXT;
+    BEGIN
+        FOR entry IN SELECT id, organization_id FROM activity ORDER BY organization_id, time, id
+            LOOP
+                IF organization IS DISTINCT FROM entry.organization_id THEN
+                    organization := entry.organization_id;
+                    next_seq := 0;
+                    last_hash := repeat('0', 64);
+                END IF;
+                next_seq := next_seq + 1;
+                UPDATE activity SET seq = next_seq, prev_hash = last_hash WHERE id = entry.id;
+                UPDATE activity SET row_hash = activity_row_hash(activity) WHERE id = entry.id
+                RETURNING row_hash INTO last_hash;
+            END LOOP;
+    END
+$$;
+
+ALTER TABLE activity
+    ALTER COLUMN seq SET NOT NULL,
+    ALTER COLUMN prev_hash SET NOT NULL,
+    ALTER COLUMN row_hash SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS activity_organization_seq_idx
+    ON activity (organization_id, seq);
+
+-- Append each new row to its organization's chain. The advisory lock
+-- serializes writers per organization until their transaction ends, so no
+-- two rows can claim the same predecessor.
+CREATE OR REPLACE FUNCTION chain_activity() RETURNS TRIGGER AS
+$$
+DECLARE
+    last_seq  BIGINT;
+    last_hash TEXT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtextextended('activity:' || NEW.organization_id::text, 0));
+    SELECT seq, row_hash
+    INTO last_seq, last_hash
+    FROM activity
+    WHERE organization_id = NEW.organization_id
+    ORDER BY seq DESC
+    LIMIT 1;
+    NEW.seq := coalesce(last_seq, 0) + 1;
+    NEW.prev_hash := coalesce(last_hash, repeat('0', 64));
+    NEW.row_hash := activity_row_hash(NEW);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activity_chain
+    BEFORE INSERT
+    ON activity
+    FOR EACH ROW
+EXECUTE FUNCTION chain_activity();
+
+-- Activity rows are never edited or removed
+CREATE OR REPLACE FUNCTION reject_activity_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'activity is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER activity_append_only
+    BEFORE UPDATE OR DELETE
+    ON activity
+    FOR EACH ROW
+EXECUTE FUNCTION reject_activity_change();
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS stock_alerts_open_product_idx;
+DROP TABLE IF EXISTS stock_alerts;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point;
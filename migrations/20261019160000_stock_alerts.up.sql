@@ -0,0 +1,29 @@
+-- Reorder thresholds per product; a reorder_point of 0 disables alerts
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS reorder_point    NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reorder_quantity NUMERIC NOT NULL DEFAULT 0;
+
+-- Create Stock Alerts Table
+-- An alert is raised when a product falls to its reorder point and resolved
+-- when it recovers, so each dip is notified once.
+CREATE TABLE IF NOT EXISTS stock_alerts
+(
+    alert_id           uuid DEFAULT uuidv7() PRIMARY KEY,
+    product_id         uuid        NOT NULL,
+    branch_uuid        uuid        NOT NULL,
+    organization_id    uuid        NOT NULL,
+    remaining_quantity NUMERIC     NOT NULL,
+    reorder_point      NUMERIC     NOT NULL,
+    reorder_quantity   NUMERIC     NOT NULL,
+    raised_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    notified_at        TIMESTAMPTZ,
+    resolved_at        TIMESTAMPTZ,
+    FOREIGN KEY (product_id) REFERENCES products (product_id),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id)
+    );
+
+-- At most one open alert per product
+CREATE UNIQUE INDEX IF NOT EXISTS stock_alerts_open_product_idx
+    ON stock_alerts (product_id)
+    WHERE resolved_at IS NULL;
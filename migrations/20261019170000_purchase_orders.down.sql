@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS goods_received_lines;
+DROP TABLE IF EXISTS goods_received_notes;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP INDEX IF EXISTS purchase_orders_organization_idx;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TYPE IF EXISTS purchase_order_status;
@@ -0,0 +1,95 @@
+CREATE TYPE purchase_order_status AS ENUM ('draft', 'sent', 'partially_received', 'closed');
+
+-- Create Purchase Orders Table
+CREATE TABLE IF NOT EXISTS purchase_orders
+(
+    order_id        uuid DEFAULT uuidv7() PRIMARY KEY,
+    partner_id      uuid                  NOT NULL,
+    branch_uuid     uuid                  NOT NULL,
+    organization_id uuid                  NOT NULL,
+    status          purchase_order_status NOT NULL DEFAULT 'draft',
+    created_by      uuid                  NOT NULL,
+    expected_date   TIMESTAMPTZ,
+    comments        TEXT,
+    created_at      TIMESTAMPTZ           NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMPTZ,
+    closed_at       TIMESTAMPTZ,
+    FOREIGN KEY (partner_id) REFERENCES partners (partner_id),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (created_by) REFERENCES user_profile (id)
+    );
+
+CREATE INDEX IF NOT EXISTS purchase_orders_organization_idx
+    ON purchase_orders (organization_id, created_at);
+
+-- Create Purchase Order Lines Table
+-- Lines name products by unique_name, so an order can introduce products the
+-- branch does not stock yet; they are created when the goods arrive.
+CREATE TABLE IF NOT EXISTS purchase_order_lines
+(
+    line_id           uuid DEFAULT uuidv7() PRIMARY KEY,
+    order_id          uuid         NOT NULL,
+    unique_name       VARCHAR(255) NOT NULL,
+    product_name      VARCHAR(255) NOT NULL,
+    measurement_unit  VARCHAR(255) NOT NULL,
+    ordered_quantity  NUMERIC      NOT NULL CHECK (ordered_quantity > 0),
+    unit_price        NUMERIC      NOT NULL CHECK (unit_price >= 0),
+    selling_price     NUMERIC      NOT NULL CHECK (selling_price >= 0),
+    received_quantity NUMERIC      NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    FOREIGN KEY (order_id) REFERENCES purchase_orders (order_id) ON DELETE CASCADE,
+    UNIQUE (order_id, unique_name)
+    );
+
+-- Create Goods Received Notes Table
+-- Each note records one delivery against an order and the purchase group it
+-- was booked as.
+CREATE TABLE IF NOT EXISTS goods_received_notes
+(
+    grn_id            uuid DEFAULT uuidv7() PRIMARY KEY,
+    order_id          uuid        NOT NULL,
+    purchase_group_id uuid        NOT NULL,
+    received_by       uuid        NOT NULL,
+    comments          TEXT,
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (order_id) REFERENCES purchase_orders (order_id),
+    FOREIGN KEY (purchase_group_id) REFERENCES purchase_group (purchase_group_id),
+    FOREIGN KEY (received_by) REFERENCES user_profile (id)
+    );
+
+-- Create Goods Received Lines Table
+CREATE TABLE IF NOT EXISTS goods_received_lines
+(
+    grn_line_id       uuid DEFAULT uuidv7() PRIMARY KEY,
+    grn_id            uuid    NOT NULL,
+    order_line_id     uuid    NOT NULL,
+    received_quantity NUMERIC NOT NULL CHECK (received_quantity > 0),
+    unit_price        NUMERIC NOT NULL CHECK (unit_price >= 0),
+    FOREIGN KEY (grn_id) REFERENCES goods_received_notes (grn_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_line_id) REFERENCES purchase_order_lines (line_id),
+    UNIQUE (grn_id, order_line_id)
+    );
+
+CREATE TRIGGER purchase_orders_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON purchase_orders
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('order_id');
+
+CREATE TRIGGER purchase_order_lines_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON purchase_order_lines
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('line_id');
+
+CREATE TRIGGER goods_received_notes_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON goods_received_notes
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('grn_id');
+
+CREATE TRIGGER goods_received_lines_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON goods_received_lines
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('grn_line_id');
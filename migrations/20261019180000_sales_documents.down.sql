@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS sales_documents;
+DROP TABLE IF EXISTS document_sequences;
+ALTER TABLE branches
+    DROP COLUMN IF EXISTS contact_number,
+    DROP COLUMN IF EXISTS address;
+ALTER TABLE organization
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS pan_number;
+DROP TYPE IF EXISTS document_type;
@@ -0,0 +1,47 @@
+CREATE TYPE document_type AS ENUM ('invoice', 'receipt');
+
+-- Details printed in document headers
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS pan_number INTEGER,
+    ADD COLUMN IF NOT EXISTS address    VARCHAR(255);
+
+ALTER TABLE branches
+    ADD COLUMN IF NOT EXISTS address        VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS contact_number VARCHAR(255);
+
+-- Last number issued per branch and document type. Numbers are taken by
+-- updating this row, which holds a lock until the document is saved, so
+-- they are sequential without gaps.
+CREATE TABLE IF NOT EXISTS document_sequences
+(
+    branch_uuid   uuid          NOT NULL,
+    document_type document_type NOT NULL,
+    last_number   BIGINT        NOT NULL,
+    PRIMARY KEY (branch_uuid, document_type),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id)
+    );
+
+-- Create Sales Documents Table
+CREATE TABLE IF NOT EXISTS sales_documents
+(
+    document_id     uuid DEFAULT uuidv7() PRIMARY KEY,
+    sales_group_id  uuid          NOT NULL,
+    branch_uuid     uuid          NOT NULL,
+    organization_id uuid          NOT NULL,
+    document_type   document_type NOT NULL,
+    document_number BIGINT        NOT NULL,
+    issued_by       uuid          NOT NULL,
+    issued_at       TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    FOREIGN KEY (sales_group_id) REFERENCES sales_group (sales_group_id),
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (issued_by) REFERENCES user_profile (id),
+    UNIQUE (branch_uuid, document_type, document_number),
+    UNIQUE (sales_group_id, document_type)
+    );
+
+CREATE TRIGGER sales_documents_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON sales_documents
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('document_id');
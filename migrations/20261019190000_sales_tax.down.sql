@@ -0,0 +1,15 @@
+ALTER TABLE sales_group
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS taxable_amount,
+    DROP COLUMN IF EXISTS price_mode;
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS taxable_amount,
+    DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS tax_rate_id;
+DROP INDEX IF EXISTS tax_rates_default_idx;
+DROP TABLE IF EXISTS tax_rates;
+ALTER TABLE organization
+    DROP COLUMN IF EXISTS price_mode;
+DROP TYPE IF EXISTS price_mode;
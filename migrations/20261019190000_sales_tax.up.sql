@@ -0,0 +1,49 @@
+CREATE TYPE price_mode AS ENUM ('tax_exclusive', 'tax_inclusive');
+
+-- Whether selling prices include tax
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS price_mode price_mode NOT NULL DEFAULT 'tax_exclusive';
+
+-- Create Tax Rates Table
+-- rate is a percentage; the default rate applies to products without one.
+CREATE TABLE IF NOT EXISTS tax_rates
+(
+    tax_rate_id     uuid DEFAULT uuidv7() PRIMARY KEY,
+    organization_id uuid         NOT NULL,
+    name            VARCHAR(255) NOT NULL,
+    rate            NUMERIC      NOT NULL CHECK (rate >= 0),
+    is_default      BOOLEAN      NOT NULL DEFAULT false,
+    UNIQUE (organization_id, name),
+    FOREIGN KEY (organization_id) REFERENCES organization (id)
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS tax_rates_default_idx
+    ON tax_rates (organization_id) WHERE is_default;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS tax_rate_id uuid REFERENCES tax_rates (tax_rate_id);
+
+-- Tax charged on each line and sale. total and total_amount include tax;
+-- taxable_amount is the amount before tax and profit is taken from it.
+ALTER TABLE sales
+    ADD COLUMN IF NOT EXISTS tax_rate       NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS taxable_amount NUMERIC NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount     NUMERIC NOT NULL DEFAULT 0;
+
+ALTER TABLE sales_group
+    ADD COLUMN IF NOT EXISTS price_mode     price_mode NOT NULL DEFAULT 'tax_exclusive',
+    ADD COLUMN IF NOT EXISTS taxable_amount NUMERIC    NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount     NUMERIC    NOT NULL DEFAULT 0;
+
+-- Sales recorded before tax was tracked were untaxed
+UPDATE sales
+SET taxable_amount = total;
+
+UPDATE sales_group
+SET taxable_amount = total_amount;
+
+CREATE TRIGGER tax_rates_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON tax_rates
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('tax_rate_id');
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS sales_group_partner_idx;
+ALTER TABLE sales_group
+    DROP CONSTRAINT IF EXISTS sales_group_credit_customer_check,
+    DROP COLUMN IF EXISTS partner_id;
+ALTER TABLE partners
+    DROP COLUMN IF EXISTS partner_type;
+DROP TYPE IF EXISTS partner_type;
@@ -0,0 +1,15 @@
+CREATE TYPE partner_type AS ENUM ('supplier', 'customer', 'both');
+
+-- Whether a partner supplies goods, buys them, or both
+ALTER TABLE partners
+    ADD COLUMN IF NOT EXISTS partner_type partner_type NOT NULL DEFAULT 'supplier';
+
+-- Customer a sale was made to; sales paid on credit must have one
+ALTER TABLE sales_group
+    ADD COLUMN IF NOT EXISTS partner_id uuid REFERENCES partners (partner_id),
+    ADD CONSTRAINT sales_group_credit_customer_check
+        CHECK (payment_method IS DISTINCT FROM 'credit' OR partner_id IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS sales_group_partner_idx
+    ON sales_group (partner_id, sold_date)
+    WHERE partner_id IS NOT NULL;
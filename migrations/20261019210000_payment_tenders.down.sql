@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS purchase_tenders;
+DROP TABLE IF EXISTS sales_tenders;
+DROP TRIGGER IF EXISTS organization_payment_methods ON organization;
+DROP FUNCTION IF EXISTS seed_payment_methods();
+DROP TABLE IF EXISTS payment_methods;
+DROP TYPE IF EXISTS payment_method_kind;
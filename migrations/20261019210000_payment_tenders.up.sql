@@ -0,0 +1,112 @@
+CREATE TYPE payment_method_kind AS ENUM ('cash', 'card', 'bank', 'credit', 'other');
+
+-- Create Payment Methods Table
+-- The payment methods an organization accepts. kind decides how a tender is
+-- treated: cash can give change and credit is owed by or to the partner.
+CREATE TABLE IF NOT EXISTS payment_methods
+(
+    method_id       uuid DEFAULT uuidv7() PRIMARY KEY,
+    organization_id uuid                NOT NULL,
+    name            VARCHAR(255)        NOT NULL,
+    kind            payment_method_kind NOT NULL,
+    is_active       BOOLEAN             NOT NULL DEFAULT true,
+    UNIQUE (organization_id, name),
+    FOREIGN KEY (organization_id) REFERENCES organization (id)
+    );
+
+-- Create Sales Tenders Table
+-- The tenders settling a sale sum to its total_amount. tendered is the cash
+-- handed over and change_due what was given back.
+CREATE TABLE IF NOT EXISTS sales_tenders
+(
+    tender_id      uuid DEFAULT uuidv7() PRIMARY KEY,
+    sales_group_id uuid    NOT NULL,
+    method_id      uuid    NOT NULL,
+    amount         NUMERIC NOT NULL CHECK (amount >= 0),
+    tendered       NUMERIC NOT NULL,
+    change_due     NUMERIC NOT NULL DEFAULT 0 CHECK (change_due >= 0),
+    UNIQUE (sales_group_id, method_id),
+    FOREIGN KEY (sales_group_id) REFERENCES sales_group (sales_group_id),
+    FOREIGN KEY (method_id) REFERENCES payment_methods (method_id)
+    );
+
+-- Create Purchase Tenders Table
+CREATE TABLE IF NOT EXISTS purchase_tenders
+(
+    tender_id         uuid DEFAULT uuidv7() PRIMARY KEY,
+    purchase_group_id uuid    NOT NULL,
+    method_id         uuid    NOT NULL,
+    amount            NUMERIC NOT NULL CHECK (amount >= 0),
+    UNIQUE (purchase_group_id, method_id),
+    FOREIGN KEY (purchase_group_id) REFERENCES purchase_group (purchase_group_id),
+    FOREIGN KEY (method_id) REFERENCES payment_methods (method_id)
+    );
+
+-- Every organization starts with cash, card, bank and credit
+CREATE OR REPLACE FUNCTION seed_payment_methods() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO payment_methods (organization_id, name, kind)
+    VALUES (NEW.id, 'cash', 'cash'),
+           (NEW.id, 'card', 'card'),
+           (NEW.id, 'bank', 'bank'),
+           (NEW.id, 'credit', 'credit')
+    ON CONFLICT (organization_id, name) DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER organization_payment_methods
+    AFTER INSERT
+    ON organization
+    FOR EACH ROW
+EXECUTE FUNCTION seed_payment_methods();
+
+INSERT INTO payment_methods (organization_id, name, kind)
+SELECT o.id, m.name, m.name::payment_method_kind
+FROM organization o
+         CROSS JOIN (VALUES ('cash'), ('card'), ('bank'), ('credit')) AS m(name)
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+-- Payment methods already used by earlier sales and purchases
+INSERT INTO payment_methods (organization_id, name, kind)
+SELECT DISTINCT organization_id, payment_method, 'other'::payment_method_kind
+FROM (SELECT organization_id, payment_method
+      FROM sales_group
+      UNION ALL
+      SELECT organization_id, payment_method
+      FROM purchase_group) used
+WHERE payment_method IS NOT NULL
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+-- Earlier sales and purchases were settled by a single tender
+INSERT INTO sales_tenders (sales_group_id, method_id, amount, tendered)
+SELECT sg.sales_group_id, m.method_id, sg.total_amount, sg.total_amount
+FROM sales_group sg
+         INNER JOIN payment_methods m ON m.organization_id = sg.organization_id AND m.name = sg.payment_method;
+
+INSERT INTO purchase_tenders (purchase_group_id, method_id, amount)
+SELECT pg.purchase_group_id, m.method_id, pg.total_cost
+FROM purchase_group pg
+         INNER JOIN payment_methods m ON m.organization_id = pg.organization_id AND m.name = pg.payment_method;
+
+CREATE INDEX IF NOT EXISTS sales_tenders_method_idx ON sales_tenders (method_id);
+CREATE INDEX IF NOT EXISTS purchase_tenders_method_idx ON purchase_tenders (method_id);
+
+CREATE TRIGGER payment_methods_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON payment_methods
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('method_id');
+
+CREATE TRIGGER sales_tenders_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON sales_tenders
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('tender_id');
+
+CREATE TRIGGER purchase_tenders_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON purchase_tenders
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('tender_id');
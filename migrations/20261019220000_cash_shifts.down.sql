@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS sales_group_shift_idx;
+ALTER TABLE sales_group
+    DROP COLUMN IF EXISTS shift_id;
+DROP INDEX IF EXISTS shifts_branch_idx;
+DROP INDEX IF EXISTS shifts_open_user_idx;
+DROP TABLE IF EXISTS shifts;
+DROP TYPE IF EXISTS shift_status;
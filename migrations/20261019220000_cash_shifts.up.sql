@@ -0,0 +1,46 @@
+CREATE TYPE shift_status AS ENUM ('open', 'closed');
+
+-- Create Shifts Table
+-- A user's session at a branch till, from the opening float to the cash
+-- counted at close. expected_cash and counted_cash are set when the shift
+-- is closed.
+CREATE TABLE IF NOT EXISTS shifts
+(
+    shift_id        uuid DEFAULT uuidv7() PRIMARY KEY,
+    branch_uuid     uuid         NOT NULL,
+    organization_id uuid         NOT NULL,
+    user_profile_id uuid         NOT NULL,
+    status          shift_status NOT NULL DEFAULT 'open',
+    opening_float   NUMERIC      NOT NULL CHECK (opening_float >= 0),
+    opened_at       TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expected_cash   NUMERIC      NOT NULL DEFAULT 0,
+    counted_cash    NUMERIC      NOT NULL DEFAULT 0,
+    closed_at       TIMESTAMPTZ,
+    closed_by       uuid,
+    comments        TEXT,
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (user_profile_id) REFERENCES user_profile (id),
+    FOREIGN KEY (closed_by) REFERENCES user_profile (id)
+    );
+
+-- A user has at most one open shift per branch
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_open_user_idx
+    ON shifts (user_profile_id, branch_uuid)
+    WHERE status = 'open';
+
+CREATE INDEX IF NOT EXISTS shifts_branch_idx
+    ON shifts (branch_uuid, opened_at);
+
+-- Shift the sale was rung up in
+ALTER TABLE sales_group
+    ADD COLUMN IF NOT EXISTS shift_id uuid REFERENCES shifts (shift_id);
+
+CREATE INDEX IF NOT EXISTS sales_group_shift_idx
+    ON sales_group (shift_id)
+    WHERE shift_id IS NOT NULL;
+
+CREATE TRIGGER shifts_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON shifts
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('shift_id');
@@ -0,0 +1,11 @@
+ALTER TABLE purchases
+    DROP COLUMN IF EXISTS purchased_quantity,
+    DROP COLUMN IF EXISTS purchased_unit;
+ALTER TABLE sales
+    DROP COLUMN IF EXISTS sold_quantity,
+    DROP COLUMN IF EXISTS sold_unit;
+DROP TRIGGER IF EXISTS organization_units ON organization;
+DROP FUNCTION IF EXISTS seed_units();
+DROP FUNCTION IF EXISTS product_unit_factor(uuid, TEXT);
+DROP TABLE IF EXISTS product_units;
+DROP TABLE IF EXISTS units;
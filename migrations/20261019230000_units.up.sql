@@ -0,0 +1,130 @@
+-- Create Units Table
+-- Units of measure of an organization. A unit is factor times its
+-- base_unit; base units have themselves as base_unit and a factor of 1.
+-- Units with the same base unit convert into each other.
+CREATE TABLE IF NOT EXISTS units
+(
+    unit_id         uuid DEFAULT uuidv7() PRIMARY KEY,
+    organization_id uuid         NOT NULL,
+    name            VARCHAR(255) NOT NULL,
+    base_unit       VARCHAR(255) NOT NULL,
+    factor          NUMERIC      NOT NULL CHECK (factor > 0),
+    UNIQUE (organization_id, name),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (organization_id, base_unit) REFERENCES units (organization_id, name)
+    );
+
+-- Create Product Units Table
+-- Packaging of a product, such as a box of 12: one unit_name is factor of
+-- the product's measurement_unit.
+CREATE TABLE IF NOT EXISTS product_units
+(
+    product_id uuid         NOT NULL,
+    unit_name  VARCHAR(255) NOT NULL,
+    factor     NUMERIC      NOT NULL CHECK (factor > 0),
+    PRIMARY KEY (product_id, unit_name),
+    FOREIGN KEY (product_id) REFERENCES products (product_id) ON DELETE CASCADE
+    );
+
+-- Quantity of a product's measurement_unit in one unit, or NULL when the
+-- unit does not convert to it
+CREATE OR REPLACE FUNCTION product_unit_factor(product uuid, unit TEXT) RETURNS NUMERIC AS
+$$
+SELECT CASE
+           WHEN p.measurement_unit = unit THEN 1
+           ELSE COALESCE((SELECT pu.factor
+                          FROM product_units pu
+                          WHERE pu.product_id = p.product_id
+                            AND pu.unit_name = unit),
+                         (SELECT f.factor / t.factor
+                          FROM units f
+                                   INNER JOIN units t
+                                              ON t.organization_id = f.organization_id AND t.base_unit = f.base_unit
+                          WHERE f.organization_id = p.organization_id
+                            AND f.name = unit
+                            AND t.name = p.measurement_unit))
+           END
+FROM products p
+WHERE p.product_id = product;
+$$ LANGUAGE sql STABLE;
+
+-- Every organization starts with count, weight and volume units
+CREATE OR REPLACE FUNCTION seed_units() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO units (organization_id, name, base_unit, factor)
+    VALUES (NEW.id, 'pieces', 'pieces', 1),
+           (NEW.id, 'kg', 'kg', 1),
+           (NEW.id, 'l', 'l', 1)
+    ON CONFLICT (organization_id, name) DO NOTHING;
+    INSERT INTO units (organization_id, name, base_unit, factor)
+    VALUES (NEW.id, 'g', 'kg', 0.001),
+           (NEW.id, 'ml', 'l', 0.001)
+    ON CONFLICT (organization_id, name) DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER organization_units
+    AFTER INSERT
+    ON organization
+    FOR EACH ROW
+EXECUTE FUNCTION seed_units();
+
+INSERT INTO units (organization_id, name, base_unit, factor)
+SELECT o.id, u.name, u.name, 1
+FROM organization o
+         CROSS JOIN (VALUES ('pieces'), ('kg'), ('l')) AS u(name)
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+INSERT INTO units (organization_id, name, base_unit, factor)
+SELECT o.id, u.name, u.base_unit, u.factor
+FROM organization o
+         CROSS JOIN (VALUES ('g', 'kg', 0.001), ('ml', 'l', 0.001)) AS u(name, base_unit, factor)
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+-- Units already in use become base units of their own
+INSERT INTO units (organization_id, name, base_unit, factor)
+SELECT DISTINCT organization_id, measurement_unit, measurement_unit, 1
+FROM products
+ON CONFLICT (organization_id, name) DO NOTHING;
+
+-- Unit and quantity each line was entered in; quantity and units stay in
+-- the product's measurement_unit
+ALTER TABLE sales
+    ADD COLUMN IF NOT EXISTS sold_unit     VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS sold_quantity NUMERIC;
+
+UPDATE sales s
+SET sold_unit     = p.measurement_unit,
+    sold_quantity = s.quantity
+FROM products p
+WHERE p.product_id = s.product_id;
+
+ALTER TABLE sales
+    ALTER COLUMN sold_unit SET NOT NULL,
+    ALTER COLUMN sold_quantity SET NOT NULL;
+
+ALTER TABLE purchases
+    ADD COLUMN IF NOT EXISTS purchased_unit     VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS purchased_quantity NUMERIC;
+
+UPDATE purchases pu
+SET purchased_unit     = COALESCE((SELECT p.measurement_unit FROM products p WHERE p.product_id = pu.product_id), ''),
+    purchased_quantity = pu.units;
+
+ALTER TABLE purchases
+    ALTER COLUMN purchased_unit SET NOT NULL,
+    ALTER COLUMN purchased_quantity SET NOT NULL;
+
+CREATE TRIGGER units_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON units
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('unit_id');
+
+CREATE TRIGGER product_units_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON product_units
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('product_id');
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_codes_unique_name_idx;
+DROP TABLE IF EXISTS product_codes;
+DROP TYPE IF EXISTS product_code_type;
+DROP INDEX IF EXISTS product_variants_parent_idx;
+DROP TABLE IF EXISTS product_variants;
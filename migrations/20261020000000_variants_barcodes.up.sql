@@ -0,0 +1,53 @@
+-- Create Product Variants Table
+-- A variant, such as a size or colour, is a product of its own with its own
+-- stock and price in every branch. It is grouped under its parent product
+-- by unique_name, so the grouping holds in every branch that stocks them.
+-- options names what sets the variant apart, e.g. {"size": "M", "colour": "red"}.
+CREATE TABLE IF NOT EXISTS product_variants
+(
+    variant_id         uuid DEFAULT uuidv7() PRIMARY KEY,
+    organization_id    uuid         NOT NULL,
+    unique_name        VARCHAR(255) NOT NULL,
+    parent_unique_name VARCHAR(255) NOT NULL,
+    options            JSONB        NOT NULL DEFAULT '{}' CHECK (jsonb_typeof(options) = 'object'),
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (organization_id, unique_name),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    CHECK (unique_name <> parent_unique_name)
+    );
+
+CREATE INDEX IF NOT EXISTS product_variants_parent_idx
+    ON product_variants (organization_id, parent_unique_name);
+
+CREATE TYPE product_code_type AS ENUM ('barcode', 'sku');
+
+-- Create Product Codes Table
+-- Barcodes and SKUs of a product or variant. A code is unique within an
+-- organization and resolves to the product of the same unique_name in
+-- whichever branch it is scanned.
+CREATE TABLE IF NOT EXISTS product_codes
+(
+    code_id         uuid DEFAULT uuidv7() PRIMARY KEY,
+    organization_id uuid              NOT NULL,
+    code            VARCHAR(255)      NOT NULL CHECK (code <> ''),
+    code_type       product_code_type NOT NULL DEFAULT 'barcode',
+    unique_name     VARCHAR(255)      NOT NULL,
+    created_at      TIMESTAMPTZ       NOT NULL DEFAULT now(),
+    UNIQUE (organization_id, code),
+    FOREIGN KEY (organization_id) REFERENCES organization (id)
+    );
+
+CREATE INDEX IF NOT EXISTS product_codes_unique_name_idx
+    ON product_codes (organization_id, unique_name);
+
+CREATE TRIGGER product_variants_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON product_variants
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('variant_id');
+
+CREATE TRIGGER product_codes_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON product_codes
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('code_id');
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS products_selling_price_update ON products;
+DROP TRIGGER IF EXISTS products_selling_price_insert ON products;
+DROP FUNCTION IF EXISTS record_selling_price();
+DROP FUNCTION IF EXISTS effective_selling_price(uuid, TIMESTAMPTZ);
+DROP INDEX IF EXISTS product_prices_product_kind_starts_idx;
+DROP TABLE IF EXISTS product_prices;
+DROP TYPE IF EXISTS price_change_kind;
@@ -0,0 +1,92 @@
+CREATE TYPE price_change_kind AS ENUM ('change', 'promotion');
+
+-- Create Product Prices Table
+-- Selling prices of a product over time. A change sets the price from
+-- starts_at until the next change; a promotion overrides it from starts_at
+-- until ends_at. Changes to products.selling_price are recorded as changes
+-- starting when they were made; later rows schedule future prices.
+CREATE TABLE IF NOT EXISTS product_prices
+(
+    price_id        uuid DEFAULT uuidv7() PRIMARY KEY,
+    product_id      uuid              NOT NULL,
+    branch_uuid     uuid              NOT NULL,
+    organization_id uuid              NOT NULL,
+    kind            price_change_kind NOT NULL DEFAULT 'change',
+    selling_price   NUMERIC           NOT NULL CHECK (selling_price >= 0),
+    starts_at       TIMESTAMPTZ       NOT NULL DEFAULT now(),
+    ends_at         TIMESTAMPTZ,
+    created_by      uuid,
+    created_at      TIMESTAMPTZ       NOT NULL DEFAULT now(),
+    FOREIGN KEY (product_id) REFERENCES products (product_id) ON DELETE CASCADE,
+    FOREIGN KEY (branch_uuid) REFERENCES branches (id),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (created_by) REFERENCES user_profile (id),
+    CHECK ((kind = 'promotion') = (ends_at IS NOT NULL)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+    );
+
+CREATE INDEX IF NOT EXISTS product_prices_product_kind_starts_idx
+    ON product_prices (product_id, kind, starts_at);
+
+-- Selling price of a product at a point in time: the latest promotion
+-- running then, else the latest change started by then, else the product's
+-- selling_price.
+CREATE OR REPLACE FUNCTION effective_selling_price(product uuid, at TIMESTAMPTZ) RETURNS NUMERIC AS
+$$
+SELECT COALESCE((SELECT pp.selling_price
+                 FROM product_prices pp
+                 WHERE pp.product_id = product
+                   AND pp.kind = 'promotion'
+                   AND pp.starts_at <= at
+                   AND pp.ends_at > at
+                 ORDER BY pp.starts_at DESC, pp.price_id DESC
+                 LIMIT 1),
+                (SELECT pp.selling_price
+                 FROM product_prices pp
+                 WHERE pp.product_id = product
+                   AND pp.kind = 'change'
+                   AND pp.starts_at <= at
+                 ORDER BY pp.starts_at DESC, pp.price_id DESC
+                 LIMIT 1),
+                (SELECT p.selling_price
+                 FROM products p
+                 WHERE p.product_id = product))
+$$ LANGUAGE sql STABLE;
+
+-- Record every new selling_price, whether set directly or by a purchase
+-- creating the product, attributed to the acting user when known
+CREATE OR REPLACE FUNCTION record_selling_price() RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO product_prices (product_id, branch_uuid, organization_id, kind, selling_price, created_by)
+    VALUES (NEW.product_id, NEW.branch_uuid, NEW.organization_id, 'change', NEW.selling_price,
+            (SELECT a.user_profile_id
+             FROM auth a
+             WHERE a.user_email = nullif(current_setting('audit.identity', true), '')));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_selling_price_insert
+    AFTER INSERT
+    ON products
+    FOR EACH ROW
+EXECUTE FUNCTION record_selling_price();
+
+CREATE TRIGGER products_selling_price_update
+    AFTER UPDATE OF selling_price
+    ON products
+    FOR EACH ROW
+    WHEN (OLD.selling_price IS DISTINCT FROM NEW.selling_price)
+EXECUTE FUNCTION record_selling_price();
+
+-- Existing prices start the history
+INSERT INTO product_prices (product_id, branch_uuid, organization_id, kind, selling_price)
+SELECT product_id, branch_uuid, organization_id, 'change', selling_price
+FROM products;
+
+CREATE TRIGGER product_prices_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON product_prices
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('price_id');
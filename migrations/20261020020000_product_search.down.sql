@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS products_description_trgm_idx;
+DROP INDEX IF EXISTS products_unique_name_trgm_idx;
+DROP INDEX IF EXISTS products_product_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_document_idx;
+DROP FUNCTION IF EXISTS product_search_document(TEXT, TEXT, TEXT);
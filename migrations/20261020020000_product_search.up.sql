@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Search document of a product. Names weigh more than the description; the
+-- simple configuration keeps names and codes as written, without stemming.
+CREATE OR REPLACE FUNCTION product_search_document(product_name TEXT, unique_name TEXT, description TEXT)
+    RETURNS tsvector AS
+$$
+SELECT setweight(to_tsvector('simple', coalesce(product_name, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(unique_name, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Full-text matches on whole words and prefixes
+CREATE INDEX IF NOT EXISTS products_search_document_idx
+    ON products USING GIN (product_search_document(product_name, unique_name, description));
+
+-- Trigram matches on partial words and typos
+CREATE INDEX IF NOT EXISTS products_product_name_trgm_idx
+    ON products USING GIN (product_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS products_unique_name_trgm_idx
+    ON products USING GIN (unique_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS products_description_trgm_idx
+    ON products USING GIN (description gin_trgm_ops);
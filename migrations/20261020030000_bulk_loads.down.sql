@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bulk_loads;
+DROP TYPE IF EXISTS bulk_load_table;
@@ -0,0 +1,31 @@
+CREATE TYPE bulk_load_table AS ENUM ('purchases', 'sales', 'sales_group');
+
+-- Create Bulk Loads Table
+-- Checkpoint of a resumable bulk load of historical rows into table_name.
+-- The first rows_read data rows of the load's input have been committed,
+-- rows_loaded of them copied into the table and rows_rejected skipped by
+-- validation. Each batch advances the checkpoint in its own transaction, so
+-- an interrupted load continues after the last committed batch.
+CREATE TABLE IF NOT EXISTS bulk_loads
+(
+    organization_id uuid            NOT NULL,
+    load_name       VARCHAR(255)    NOT NULL,
+    table_name      bulk_load_table NOT NULL,
+    rows_read       BIGINT          NOT NULL DEFAULT 0 CHECK (rows_read >= 0),
+    rows_loaded     BIGINT          NOT NULL DEFAULT 0 CHECK (rows_loaded >= 0),
+    rows_rejected   BIGINT          NOT NULL DEFAULT 0 CHECK (rows_rejected >= 0),
+    started_by      uuid            NOT NULL,
+    started_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    completed_at    TIMESTAMPTZ,
+    PRIMARY KEY (organization_id, load_name),
+    FOREIGN KEY (organization_id) REFERENCES organization (id),
+    FOREIGN KEY (started_by) REFERENCES user_profile (id),
+    CHECK (rows_loaded + rows_rejected = rows_read)
+    );
+
+CREATE TRIGGER bulk_loads_activity
+    AFTER INSERT OR UPDATE OR DELETE
+    ON bulk_loads
+    FOR EACH ROW
+EXECUTE FUNCTION record_activity('load_name');
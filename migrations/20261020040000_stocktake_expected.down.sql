@@ -0,0 +1,2 @@
+ALTER TABLE stocktake_counts
+    DROP COLUMN IF EXISTS expected_quantity;
@@ -0,0 +1,13 @@
+-- Stock a product was expected to hold when it was counted; posting adjusts
+-- by the difference to the count, so stock moved after counting stays put
+ALTER TABLE stocktake_counts
+    ADD COLUMN IF NOT EXISTS expected_quantity NUMERIC;
+
+UPDATE stocktake_counts c
+SET expected_quantity = p.remaining_quantity
+FROM products p
+WHERE p.product_id = c.product_id
+  AND c.expected_quantity IS NULL;
+
+ALTER TABLE stocktake_counts
+    ALTER COLUMN expected_quantity SET NOT NULL;
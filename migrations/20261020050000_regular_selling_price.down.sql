@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS products_selling_price_update ON products;
+CREATE TRIGGER products_selling_price_update
+    AFTER UPDATE OF selling_price
+    ON products
+    FOR EACH ROW
+    WHEN (OLD.selling_price IS DISTINCT FROM NEW.selling_price)
+EXECUTE FUNCTION record_selling_price();
+
+DROP FUNCTION IF EXISTS regular_selling_price(uuid, TIMESTAMPTZ);
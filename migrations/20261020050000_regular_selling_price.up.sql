@@ -0,0 +1,26 @@
+-- Regular selling price of a product at a point in time: the latest change
+-- started by then, else the product's selling_price. Promotions are left
+-- out, so copies of a product do not keep a temporary price for good.
+CREATE OR REPLACE FUNCTION regular_selling_price(product uuid, at TIMESTAMPTZ) RETURNS NUMERIC AS
+$$
+SELECT COALESCE((SELECT pp.selling_price
+                 FROM product_prices pp
+                 WHERE pp.product_id = product
+                   AND pp.kind = 'change'
+                   AND pp.starts_at <= at
+                 ORDER BY pp.starts_at DESC, pp.price_id DESC
+                 LIMIT 1),
+                (SELECT p.selling_price
+                 FROM products p
+                 WHERE p.product_id = product))
+$$ LANGUAGE sql STABLE;
+
+-- Record every price set directly, even one equal to the stored
+-- selling_price: a scheduled change may have taken effect since, and setting
+-- the earlier price again must override it
+DROP TRIGGER IF EXISTS products_selling_price_update ON products;
+CREATE TRIGGER products_selling_price_update
+    AFTER UPDATE OF selling_price
+    ON products
+    FOR EACH ROW
+EXECUTE FUNCTION record_selling_price();
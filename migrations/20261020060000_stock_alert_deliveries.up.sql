@@ -0,0 +1,11 @@
+-- Create Stock Alert Deliveries Table
+-- Channels an alert was delivered through. An alert is notified once every
+-- channel has delivered it; a channel that fails is retried alone.
+CREATE TABLE IF NOT EXISTS stock_alert_deliveries
+(
+    alert_id     uuid        NOT NULL,
+    channel      TEXT        NOT NULL,
+    delivered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (alert_id, channel),
+    FOREIGN KEY (alert_id) REFERENCES stock_alerts (alert_id) ON DELETE CASCADE
+    );
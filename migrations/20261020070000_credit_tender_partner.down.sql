@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS purchase_tenders_credit_partner ON purchase_tenders;
+DROP TRIGGER IF EXISTS sales_tenders_credit_partner ON sales_tenders;
+DROP FUNCTION IF EXISTS check_credit_tender_partner();
@@ -0,0 +1,33 @@
+-- Credit tenders are owed by or to a partner, so the sale or purchase they
+-- settle must name one, whatever the payment method is called
+CREATE OR REPLACE FUNCTION check_credit_tender_partner() RETURNS TRIGGER AS
+$$
+DECLARE
+    partner uuid;
+BEGIN
+    IF (SELECT m.kind FROM payment_methods m WHERE m.method_id = NEW.method_id) IS DISTINCT FROM 'credit' THEN
+        RETURN NEW;
+    END IF;
+    IF TG_TABLE_NAME = 'sales_tenders' THEN
+        SELECT sg.partner_id INTO partner FROM sales_group sg WHERE sg.sales_group_id = NEW.sales_group_id;
+    ELSE
+        SELECT pg.partner_id INTO partner FROM purchase_group pg WHERE pg.purchase_group_id = NEW.purchase_group_id;
+    END IF;
+    IF partner IS NULL THEN
+        RAISE EXCEPTION 'credit tenders need a partner' USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sales_tenders_credit_partner
+    BEFORE INSERT OR UPDATE OF method_id, sales_group_id
+    ON sales_tenders
+    FOR EACH ROW
+EXECUTE FUNCTION check_credit_tender_partner();
+
+CREATE TRIGGER purchase_tenders_credit_partner
+    BEFORE INSERT OR UPDATE OF method_id, purchase_group_id
+    ON purchase_tenders
+    FOR EACH ROW
+EXECUTE FUNCTION check_credit_tender_partner();
@@ -0,0 +1,295 @@
+// Package partners manages suppliers and customers and their running
+// balances.
+//
+// A partner is a supplier, a customer or both. A partner's balance combines
+// credit purchases from and credit sales to the partner with the payments
+// and receipts recorded against the partner. A positive balance is owed to
+// the partner (a payable); a negative balance is owed by the partner (a
+// receivable). Credit purchases and receipts raise the balance, credit sales
+// and payments lower it.
+package partners
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// Record types of partner_payment_receipt rows.
+const (
+	// RecordTypePayment is money paid to the partner.
+	RecordTypePayment = "payment"
+	// RecordTypeReceipt is money received from the partner.
+	RecordTypeReceipt = "receipt"
+)
+
+// CreditPaymentMethod is the name of the credit payment method every
+// organization starts with. Tenders of any method of the credit kind count
+// towards a partner's balance.
+const CreditPaymentMethod = "credit"
+
+var (
+	// ErrPartnerNotFound is returned when a partner does not exist in the organization.
+	ErrPartnerNotFound = errors.New("partners: partner not found")
+	// ErrInvalidPartner is returned when required partner fields are missing.
+	ErrInvalidPartner = errors.New("partners: unique_name and partner_name are required")
+	// ErrInvalidAmount is returned when a payment or receipt amount is not positive.
+	ErrInvalidAmount = errors.New("partners: amount must be positive")
+	// ErrInvalidRecordType is returned for record types other than payment and receipt.
+	ErrInvalidRecordType = errors.New("partners: record type must be payment or receipt")
+	// ErrInvalidPartnerType is returned for partner types other than supplier, customer and both.
+	ErrInvalidPartnerType = errors.New("partners: partner type must be supplier, customer or both")
+	// ErrNotCustomer is returned when a sale is attached to a partner that is only a supplier.
+	ErrNotCustomer = errors.New("partners: partner is not a customer")
+	// ErrPartnerInUse is returned when deleting a partner with purchases, sales, payments or orders on record.
+	ErrPartnerInUse = errors.New("partners: partner has records and cannot be deleted")
+)
+
+// Service manages partners and their ledgers.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a partners Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Create adds a partner. A partner without a type is a supplier.
+func (s *Service) Create(ctx context.Context, arg generated.InsertPartnerParams) (generated.Partner, error) {
+	if arg.UniqueName == "" || arg.PartnerName == "" {
+		return generated.Partner{}, ErrInvalidPartner
+	}
+	if arg.PartnerType == "" {
+		arg.PartnerType = generated.PartnerTypeSupplier
+	}
+	if !validType(arg.PartnerType) {
+		return generated.Partner{}, fmt.Errorf("%q: %w", arg.PartnerType, ErrInvalidPartnerType)
+	}
+	var partner generated.Partner
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		partner, err = q.InsertPartner(ctx, arg)
+		if err != nil {
+			return fmt.Errorf("insert partner: %w", err)
+		}
+		return nil
+	})
+	return partner, err
+}
+
+// Get returns a partner.
+func (s *Service) Get(ctx context.Context, partnerID, organizationID uuid.UUID) (generated.Partner, error) {
+	partner, err := s.db.Queries().GetPartner(ctx, generated.GetPartnerParams{
+		PartnerID:      partnerID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return partner, ErrPartnerNotFound
+	}
+	if err != nil {
+		return partner, fmt.Errorf("get partner: %w", err)
+	}
+	return partner, nil
+}
+
+// List returns the partners of an organization by name.
+func (s *Service) List(ctx context.Context, organizationID uuid.UUID) ([]generated.Partner, error) {
+	return s.db.Queries().ListPartners(ctx, organizationID)
+}
+
+// Customers returns the partners of an organization that are customers, by
+// name.
+func (s *Service) Customers(ctx context.Context, organizationID uuid.UUID) ([]generated.Partner, error) {
+	return s.db.Queries().ListPartnersByType(ctx, generated.ListPartnersByTypeParams{
+		OrganizationID: organizationID,
+		PartnerType:    generated.PartnerTypeCustomer,
+	})
+}
+
+// Suppliers returns the partners of an organization that are suppliers, by
+// name.
+func (s *Service) Suppliers(ctx context.Context, organizationID uuid.UUID) ([]generated.Partner, error) {
+	return s.db.Queries().ListPartnersByType(ctx, generated.ListPartnersByTypeParams{
+		OrganizationID: organizationID,
+		PartnerType:    generated.PartnerTypeSupplier,
+	})
+}
+
+// Update changes the contact details of a partner, and its type when
+// arg.PartnerType is valid. The unique_name and branch of a partner cannot
+// be changed.
+func (s *Service) Update(ctx context.Context, arg generated.UpdatePartnerParams) (generated.Partner, error) {
+	if arg.PartnerName == "" {
+		return generated.Partner{}, ErrInvalidPartner
+	}
+	if arg.PartnerType.Valid && !validType(arg.PartnerType.PartnerType) {
+		return generated.Partner{}, fmt.Errorf("%q: %w", arg.PartnerType.PartnerType, ErrInvalidPartnerType)
+	}
+	var partner generated.Partner
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		partner, err = q.UpdatePartner(ctx, arg)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPartnerNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update partner: %w", err)
+		}
+		return nil
+	})
+	return partner, err
+}
+
+// Delete removes a partner. Partners with purchases, sales, payments or
+// purchase orders on record cannot be deleted.
+func (s *Service) Delete(ctx context.Context, partnerID, organizationID uuid.UUID) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		inUse, err := q.PartnerInUse(ctx, partnerID)
+		if err != nil {
+			return fmt.Errorf("check partner records: %w", err)
+		}
+		if inUse {
+			return ErrPartnerInUse
+		}
+		n, err := q.DeletePartner(ctx, generated.DeletePartnerParams{
+			PartnerID:      partnerID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return fmt.Errorf("delete partner: %w", err)
+		}
+		if n == 0 {
+			return ErrPartnerNotFound
+		}
+		return nil
+	})
+}
+
+// RequireCustomer returns a partner that can be sold to.
+func RequireCustomer(ctx context.Context, q *generated.Queries, partnerID, organizationID uuid.UUID) (generated.Partner, error) {
+	partner, err := q.GetPartner(ctx, generated.GetPartnerParams{
+		PartnerID:      partnerID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return partner, ErrPartnerNotFound
+	}
+	if err != nil {
+		return partner, fmt.Errorf("get partner: %w", err)
+	}
+	if partner.PartnerType == generated.PartnerTypeSupplier {
+		return partner, fmt.Errorf("%s: %w", partner.UniqueName, ErrNotCustomer)
+	}
+	return partner, nil
+}
+
+func validType(t generated.PartnerType) bool {
+	switch t {
+	case generated.PartnerTypeSupplier, generated.PartnerTypeCustomer, generated.PartnerTypeBoth:
+		return true
+	}
+	return false
+}
+
+// RecordPaymentReceipt records a payment to or a receipt from a partner.
+func (s *Service) RecordPaymentReceipt(ctx context.Context, arg generated.InsertPartnerPaymentReceiptParams) (generated.PartnerPaymentReceipt, error) {
+	var record generated.PartnerPaymentReceipt
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		record, err = RecordPaymentReceipt(ctx, q, arg)
+		return err
+	})
+	return record, err
+}
+
+// RecordPaymentReceipt records a payment or receipt with q, for callers that
+// already hold a transaction.
+func RecordPaymentReceipt(ctx context.Context, q *generated.Queries, arg generated.InsertPartnerPaymentReceiptParams) (generated.PartnerPaymentReceipt, error) {
+	if arg.RecordType.String != RecordTypePayment && arg.RecordType.String != RecordTypeReceipt {
+		return generated.PartnerPaymentReceipt{}, ErrInvalidRecordType
+	}
+	if !arg.Amount.IsPositive() {
+		return generated.PartnerPaymentReceipt{}, ErrInvalidAmount
+	}
+	_, err := q.GetPartner(ctx, generated.GetPartnerParams{
+		PartnerID:      arg.PartnerID,
+		OrganizationID: arg.OrganizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return generated.PartnerPaymentReceipt{}, ErrPartnerNotFound
+	}
+	if err != nil {
+		return generated.PartnerPaymentReceipt{}, fmt.Errorf("get partner: %w", err)
+	}
+	record, err := q.InsertPartnerPaymentReceipt(ctx, arg)
+	if err != nil {
+		return record, fmt.Errorf("insert %s: %w", arg.RecordType.String, err)
+	}
+	return record, nil
+}
+
+// Balance returns the balance of a partner as of a point in time.
+func (s *Service) Balance(ctx context.Context, partnerID, organizationID uuid.UUID, asOf time.Time) (decimal.Decimal, error) {
+	return s.db.Queries().GetPartnerBalance(ctx, generated.GetPartnerBalanceParams{
+		PartnerID:      partnerID,
+		OrganizationID: organizationID,
+		AsOf:           asOf,
+	})
+}
+
+// Balances returns the current balance of every partner of an organization.
+func (s *Service) Balances(ctx context.Context, organizationID uuid.UUID) ([]generated.ListPartnerBalancesRow, error) {
+	return s.db.Queries().ListPartnerBalances(ctx, organizationID)
+}
+
+// Statement is a partner's ledger over a date range.
+type Statement struct {
+	Partner        generated.Partner                       `json:"partner"`
+	From           time.Time                               `json:"from"`
+	To             time.Time                               `json:"to"`
+	OpeningBalance decimal.Decimal                         `json:"opening_balance"`
+	Entries        []generated.ListPartnerLedgerEntriesRow `json:"entries"`
+	ClosingBalance decimal.Decimal                         `json:"closing_balance"`
+}
+
+// Statement returns the entries of a partner from from (inclusive) to to
+// (exclusive), each with the running balance after it.
+func (s *Service) Statement(ctx context.Context, partnerID, organizationID uuid.UUID, from, to time.Time) (Statement, error) {
+	statement := Statement{From: from, To: to}
+	q := s.db.Queries()
+	var err error
+	statement.Partner, err = s.Get(ctx, partnerID, organizationID)
+	if err != nil {
+		return statement, err
+	}
+	statement.OpeningBalance, err = q.GetPartnerBalance(ctx, generated.GetPartnerBalanceParams{
+		PartnerID:      partnerID,
+		OrganizationID: organizationID,
+		AsOf:           from,
+	})
+	if err != nil {
+		return statement, fmt.Errorf("get opening balance: %w", err)
+	}
+	statement.Entries, err = q.ListPartnerLedgerEntries(ctx, generated.ListPartnerLedgerEntriesParams{
+		PartnerID:      partnerID,
+		OrganizationID: organizationID,
+		ToDate:         to,
+		FromDate:       from,
+	})
+	if err != nil {
+		return statement, fmt.Errorf("list ledger entries: %w", err)
+	}
+	statement.ClosingBalance = statement.OpeningBalance
+	if n := len(statement.Entries); n > 0 {
+		statement.ClosingBalance = statement.Entries[n-1].Balance
+	}
+	return statement, nil
+}
@@ -0,0 +1,303 @@
+// Package payments manages the payment methods an organization accepts and
+// settles sales and purchases by one or more tenders.
+//
+// The tenders of a settlement must sum to the total being settled. Cash
+// tenders may be handed over in excess of their amount; the excess is the
+// change due. Tenders of a credit method are owed by the customer, or to the
+// supplier, and show up in the partner's balance.
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// SplitPaymentMethod is stored as the payment_method of a sale or purchase
+// settled by more than one tender.
+const SplitPaymentMethod = "split"
+
+var (
+	// ErrUnknownMethod is returned when a tender names a method the organization does not have.
+	ErrUnknownMethod = errors.New("payments: unknown payment method")
+	// ErrInactiveMethod is returned when a tender names a method that has been turned off.
+	ErrInactiveMethod = errors.New("payments: payment method is not active")
+	// ErrInvalidMethod is returned when a method has no name or an unknown kind.
+	ErrInvalidMethod = errors.New("payments: payment method needs a name and a known kind")
+	// ErrMethodNotFound is returned when a payment method does not exist in the organization.
+	ErrMethodNotFound = errors.New("payments: payment method not found")
+	// ErrInvalidTender is returned when a tender amount is negative.
+	ErrInvalidTender = errors.New("payments: tender amount must not be negative")
+	// ErrDuplicateTender is returned when a settlement names a method twice.
+	ErrDuplicateTender = errors.New("payments: payment method tendered twice")
+	// ErrNoTenders is returned when a positive total is settled by no tenders.
+	ErrNoTenders = errors.New("payments: a positive total needs at least one tender")
+	// ErrTenderMismatch is returned when tenders do not sum to the total.
+	ErrTenderMismatch = errors.New("payments: tenders do not sum to the total")
+	// ErrInsufficientCash is returned when the cash handed over is less than the cash tender.
+	ErrInsufficientCash = errors.New("payments: cash tendered is less than the amount")
+	// ErrChangeNotCash is returned when more than the amount is handed over by a method other than cash.
+	ErrChangeNotCash = errors.New("payments: only cash tenders can give change")
+)
+
+// adminRoles may manage the payment methods of an organization.
+var adminRoles = []access.Role{access.RoleAdmin}
+
+// Tender is part of a payment. Tendered is the cash handed over for a cash
+// tender and may exceed Amount; it defaults to Amount.
+type Tender struct {
+	Method   string
+	Amount   decimal.Decimal
+	Tendered decimal.NullDecimal
+}
+
+// SettledTender is a tender checked against the organization's methods.
+type SettledTender struct {
+	MethodID  uuid.UUID                   `json:"method_id"`
+	Method    string                      `json:"method"`
+	Kind      generated.PaymentMethodKind `json:"kind"`
+	Amount    decimal.Decimal             `json:"amount"`
+	Tendered  decimal.Decimal             `json:"tendered"`
+	ChangeDue decimal.Decimal             `json:"change_due"`
+}
+
+// Settlement is the tenders settling a total.
+type Settlement struct {
+	Tenders []SettledTender `json:"tenders"`
+	// ChangeDue is the change to give back over all cash tenders.
+	ChangeDue decimal.Decimal `json:"change_due"`
+	// Credit is the part of the total settled on credit.
+	Credit decimal.Decimal `json:"credit"`
+}
+
+// PaymentMethod is the payment_method stored on the settled group: the
+// method of a single tender, SplitPaymentMethod for several, or none.
+func (s Settlement) PaymentMethod() sql.NullString {
+	switch len(s.Tenders) {
+	case 0:
+		return sql.NullString{}
+	case 1:
+		return sql.NullString{String: s.Tenders[0].Method, Valid: true}
+	}
+	return sql.NullString{String: SplitPaymentMethod, Valid: true}
+}
+
+// Single returns the tender paying a whole total by one method, or none
+// when method is empty.
+func Single(method string, total decimal.Decimal) []Tender {
+	if method == "" {
+		return nil
+	}
+	return []Tender{{Method: method, Amount: total}}
+}
+
+// ChangeDue returns the change for cash handed over against amount, or zero
+// when it does not cover the amount.
+func ChangeDue(amount, tendered decimal.Decimal) decimal.Decimal {
+	if tendered.LessThanOrEqual(amount) {
+		return decimal.Zero
+	}
+	return tendered.Sub(amount)
+}
+
+// Settle checks tenders against the active payment methods of an
+// organization and against total. Only a zero total may be settled by no
+// tenders; callers recording an unpaid sale or purchase skip Settle.
+func Settle(ctx context.Context, q *generated.Queries, organizationID uuid.UUID, total decimal.Decimal, tenders []Tender) (Settlement, error) {
+	if len(tenders) == 0 {
+		if total.IsPositive() {
+			return Settlement{}, ErrNoTenders
+		}
+		return Settlement{}, nil
+	}
+	methods, err := q.ListPaymentMethods(ctx, organizationID)
+	if err != nil {
+		return Settlement{}, fmt.Errorf("list payment methods: %w", err)
+	}
+	byName := make(map[string]generated.PaymentMethod, len(methods))
+	for _, m := range methods {
+		byName[m.Name] = m
+	}
+
+	settlement := Settlement{Tenders: make([]SettledTender, 0, len(tenders))}
+	seen := make(map[uuid.UUID]bool, len(tenders))
+	sum := decimal.Zero
+	for _, t := range tenders {
+		m, ok := byName[t.Method]
+		if !ok {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrUnknownMethod)
+		}
+		if !m.IsActive {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrInactiveMethod)
+		}
+		if seen[m.MethodID] {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrDuplicateTender)
+		}
+		seen[m.MethodID] = true
+		if t.Amount.IsNegative() {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrInvalidTender)
+		}
+
+		tendered := t.Amount
+		if t.Tendered.Valid {
+			tendered = t.Tendered.Decimal
+		}
+		if tendered.LessThan(t.Amount) {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrInsufficientCash)
+		}
+		change := ChangeDue(t.Amount, tendered)
+		if change.IsPositive() && m.Kind != generated.PaymentMethodKindCash {
+			return Settlement{}, fmt.Errorf("%q: %w", t.Method, ErrChangeNotCash)
+		}
+
+		settlement.Tenders = append(settlement.Tenders, SettledTender{
+			MethodID:  m.MethodID,
+			Method:    m.Name,
+			Kind:      m.Kind,
+			Amount:    t.Amount,
+			Tendered:  tendered,
+			ChangeDue: change,
+		})
+		settlement.ChangeDue = settlement.ChangeDue.Add(change)
+		if m.Kind == generated.PaymentMethodKindCredit {
+			settlement.Credit = settlement.Credit.Add(t.Amount)
+		}
+		sum = sum.Add(t.Amount)
+	}
+	if !sum.Equal(total) {
+		return Settlement{}, fmt.Errorf("tenders sum to %s, total is %s: %w", sum, total, ErrTenderMismatch)
+	}
+	return settlement, nil
+}
+
+// RecordSaleTenders saves the tenders of a settled sale.
+func RecordSaleTenders(ctx context.Context, q *generated.Queries, salesGroupID uuid.UUID, settlement Settlement) ([]generated.SalesTender, error) {
+	saved := make([]generated.SalesTender, 0, len(settlement.Tenders))
+	for _, t := range settlement.Tenders {
+		tender, err := q.InsertSalesTender(ctx, generated.InsertSalesTenderParams{
+			SalesGroupID: salesGroupID,
+			MethodID:     t.MethodID,
+			Amount:       t.Amount,
+			Tendered:     t.Tendered,
+			ChangeDue:    t.ChangeDue,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("insert %s tender: %w", t.Method, err)
+		}
+		saved = append(saved, tender)
+	}
+	return saved, nil
+}
+
+// RecordPurchaseTenders saves the tenders of a settled purchase.
+func RecordPurchaseTenders(ctx context.Context, q *generated.Queries, purchaseGroupID uuid.UUID, settlement Settlement) ([]generated.PurchaseTender, error) {
+	saved := make([]generated.PurchaseTender, 0, len(settlement.Tenders))
+	for _, t := range settlement.Tenders {
+		tender, err := q.InsertPurchaseTender(ctx, generated.InsertPurchaseTenderParams{
+			PurchaseGroupID: purchaseGroupID,
+			MethodID:        t.MethodID,
+			Amount:          t.Amount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("insert %s tender: %w", t.Method, err)
+		}
+		saved = append(saved, tender)
+	}
+	return saved, nil
+}
+
+// Service manages payment methods.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a payments Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Methods returns the payment methods of an organization by name,
+// including inactive ones.
+func (s *Service) Methods(ctx context.Context, organizationID uuid.UUID) ([]generated.PaymentMethod, error) {
+	return s.db.Queries().ListPaymentMethods(ctx, organizationID)
+}
+
+// CreateMethod adds a payment method to an organization.
+func (s *Service) CreateMethod(ctx context.Context, organizationID, userProfileID uuid.UUID, name string, kind generated.PaymentMethodKind) (generated.PaymentMethod, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == SplitPaymentMethod || !validKind(kind) {
+		return generated.PaymentMethod{}, ErrInvalidMethod
+	}
+	var method generated.PaymentMethod
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		var err error
+		method, err = q.InsertPaymentMethod(ctx, generated.InsertPaymentMethodParams{
+			OrganizationID: organizationID,
+			Name:           name,
+			Kind:           kind,
+		})
+		if err != nil {
+			return fmt.Errorf("insert payment method: %w", err)
+		}
+		return nil
+	})
+	return method, err
+}
+
+// SetMethodActive turns a payment method on or off. Methods are never
+// deleted, so earlier tenders keep their method.
+func (s *Service) SetMethodActive(ctx context.Context, organizationID, userProfileID, methodID uuid.UUID, active bool) (generated.PaymentMethod, error) {
+	var method generated.PaymentMethod
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		var err error
+		method, err = q.SetPaymentMethodActive(ctx, generated.SetPaymentMethodActiveParams{
+			IsActive:       active,
+			MethodID:       methodID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMethodNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("set payment method active: %w", err)
+		}
+		return nil
+	})
+	return method, err
+}
+
+func validKind(kind generated.PaymentMethodKind) bool {
+	switch kind {
+	case generated.PaymentMethodKindCash, generated.PaymentMethodKindCard, generated.PaymentMethodKindBank,
+		generated.PaymentMethodKindCredit, generated.PaymentMethodKindOther:
+		return true
+	}
+	return false
+}
+
+// requireAdmin checks the user may manage the organization's payment
+// methods.
+func requireAdmin(ctx context.Context, q *generated.Queries, userProfileID, organizationID uuid.UUID) error {
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasRole(adminRoles...) {
+		return fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	return nil
+}
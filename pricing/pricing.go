@@ -0,0 +1,216 @@
+// Package pricing keeps the selling price history of products and
+// schedules future price changes and temporary promotions.
+//
+// Every change to a product's selling_price, whether set directly or by a
+// purchase that creates the product, is recorded with the time it was made.
+// Scheduled changes and promotions belong to a product and so to its
+// branch. Checkout charges the effective price at sale time: the latest
+// running promotion, else the latest change that has started.
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+var (
+	// ErrProductNotFound is returned when a product does not exist in the organization.
+	ErrProductNotFound = errors.New("pricing: product not found")
+	// ErrPriceNotFound is returned when a price does not exist in the organization.
+	ErrPriceNotFound = errors.New("pricing: price not found")
+	// ErrInvalidPrice is returned when a selling price is negative.
+	ErrInvalidPrice = errors.New("pricing: selling price must not be negative")
+	// ErrPastStart is returned when a scheduled price would start in the past.
+	ErrPastStart = errors.New("pricing: scheduled prices must start in the future")
+	// ErrInvalidPeriod is returned when a promotion does not end after it starts.
+	ErrInvalidPeriod = errors.New("pricing: promotion must end after it starts")
+	// ErrNotCancellable is returned when a price has already taken effect for good.
+	ErrNotCancellable = errors.New("pricing: price is no longer scheduled or running")
+)
+
+// managerRoles may change prices in their branches.
+var managerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// Service manages selling prices.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a pricing Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// SetPrice changes a product's selling price now, overriding any change
+// that has started, even when selling_price already holds price. Changes
+// scheduled for later still take effect when they start.
+func (s *Service) SetPrice(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, price decimal.Decimal) (generated.Product, error) {
+	if price.IsNegative() {
+		return generated.Product{}, ErrInvalidPrice
+	}
+	var product generated.Product
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, err := authorize(ctx, q, organizationID, userProfileID, productID); err != nil {
+			return err
+		}
+		var err error
+		product, err = q.UpdateProductSellingPrice(ctx, generated.UpdateProductSellingPriceParams{
+			SellingPrice:   price,
+			ProductID:      productID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return fmt.Errorf("update selling price: %w", err)
+		}
+		return nil
+	})
+	return product, err
+}
+
+// SchedulePrice changes a product's selling price from startsAt on.
+func (s *Service) SchedulePrice(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, price decimal.Decimal, startsAt time.Time) (generated.ProductPrice, error) {
+	if !startsAt.After(time.Now()) {
+		return generated.ProductPrice{}, ErrPastStart
+	}
+	return s.schedule(ctx, organizationID, userProfileID, productID, generated.InsertProductPriceParams{
+		Kind:         generated.PriceChangeKindChange,
+		SellingPrice: price,
+		StartsAt:     startsAt,
+	})
+}
+
+// SchedulePromotion sells a product at price from startsAt until endsAt,
+// after which its regular price applies again. A zero startsAt starts the
+// promotion now.
+func (s *Service) SchedulePromotion(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, price decimal.Decimal, startsAt, endsAt time.Time) (generated.ProductPrice, error) {
+	now := time.Now()
+	if startsAt.IsZero() {
+		startsAt = now
+	} else if startsAt.Before(now) {
+		return generated.ProductPrice{}, ErrPastStart
+	}
+	if !endsAt.After(startsAt) {
+		return generated.ProductPrice{}, ErrInvalidPeriod
+	}
+	return s.schedule(ctx, organizationID, userProfileID, productID, generated.InsertProductPriceParams{
+		Kind:         generated.PriceChangeKindPromotion,
+		SellingPrice: price,
+		StartsAt:     startsAt,
+		EndsAt:       sql.NullTime{Time: endsAt, Valid: true},
+	})
+}
+
+func (s *Service) schedule(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, arg generated.InsertProductPriceParams) (generated.ProductPrice, error) {
+	if arg.SellingPrice.IsNegative() {
+		return generated.ProductPrice{}, ErrInvalidPrice
+	}
+	arg.ProductID = productID
+	arg.OrganizationID = organizationID
+	arg.CreatedBy = uuid.NullUUID{UUID: userProfileID, Valid: true}
+	var price generated.ProductPrice
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, err := authorize(ctx, q, organizationID, userProfileID, productID); err != nil {
+			return err
+		}
+		var err error
+		price, err = q.InsertProductPrice(ctx, arg)
+		if err != nil {
+			return fmt.Errorf("insert product price: %w", err)
+		}
+		return nil
+	})
+	return price, err
+}
+
+// Cancel drops a price change or promotion that has not started yet, or
+// ends a running promotion now.
+func (s *Service) Cancel(ctx context.Context, organizationID, userProfileID, priceID uuid.UUID) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		price, err := q.GetProductPrice(ctx, generated.GetProductPriceParams{
+			PriceID:        priceID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPriceNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get product price: %w", err)
+		}
+		if _, err := authorize(ctx, q, organizationID, userProfileID, price.ProductID); err != nil {
+			return err
+		}
+		n, err := q.DeleteScheduledProductPrice(ctx, priceID)
+		if err != nil {
+			return fmt.Errorf("delete scheduled price: %w", err)
+		}
+		if n > 0 {
+			return nil
+		}
+		n, err = q.EndProductPromotion(ctx, priceID)
+		if err != nil {
+			return fmt.Errorf("end promotion: %w", err)
+		}
+		if n == 0 {
+			return ErrNotCancellable
+		}
+		return nil
+	})
+}
+
+// History returns the prices of a product, latest start first, including
+// those scheduled for later.
+func (s *Service) History(ctx context.Context, organizationID, productID uuid.UUID) ([]generated.ProductPrice, error) {
+	return s.db.Queries().ListProductPrices(ctx, generated.ListProductPricesParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+}
+
+// Current returns a product's regular selling price and the price it sells
+// at now.
+func (s *Service) Current(ctx context.Context, organizationID, productID uuid.UUID) (generated.GetPricedProductRow, error) {
+	product, err := s.db.Queries().GetPricedProduct(ctx, generated.GetPricedProductParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, ErrProductNotFound
+	}
+	return product, err
+}
+
+// EffectivePrice returns the selling price of a product at a point in time.
+func (s *Service) EffectivePrice(ctx context.Context, productID uuid.UUID, at time.Time) (decimal.Decimal, error) {
+	return s.db.Queries().GetEffectiveSellingPrice(ctx, generated.GetEffectiveSellingPriceParams{
+		ProductID: productID,
+		At:        at,
+	})
+}
+
+// authorize checks the user may change the prices of a product.
+func authorize(ctx context.Context, q *generated.Queries, organizationID, userProfileID, productID uuid.UUID) (generated.GetPricedProductRow, error) {
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return generated.GetPricedProductRow{}, err
+	}
+	product, err := q.GetPricedProduct(ctx, generated.GetPricedProductParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, ErrProductNotFound
+	}
+	if err != nil {
+		return product, fmt.Errorf("get product: %w", err)
+	}
+	return product, actor.RequireBranchRole(product.BranchUuid, managerRoles...)
+}
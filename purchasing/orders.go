@@ -0,0 +1,432 @@
+package purchasing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/partners"
+)
+
+var (
+	// ErrOrderNotFound is returned when a purchase order does not exist in the organization.
+	ErrOrderNotFound = errors.New("purchasing: purchase order not found")
+	// ErrOrderStatus is returned when an order is not in a status that allows the operation.
+	ErrOrderStatus = errors.New("purchasing: purchase order status does not allow this operation")
+	// ErrEmptyOrder is returned when an order or a delivery has no lines.
+	ErrEmptyOrder = errors.New("purchasing: purchase order has no lines")
+	// ErrInvalidQuantity is returned when an ordered or delivered quantity is not positive.
+	ErrInvalidQuantity = errors.New("purchasing: quantity must be positive")
+	// ErrDuplicateLine is returned when a product or order line appears twice.
+	ErrDuplicateLine = errors.New("purchasing: line appears more than once")
+	// ErrUnknownOrderLine is returned when a delivered line is not part of the order.
+	ErrUnknownOrderLine = errors.New("purchasing: line is not part of the purchase order")
+	// ErrUnknownBranch is returned when the branch does not belong to the organization.
+	ErrUnknownBranch = errors.New("purchasing: branch does not belong to the organization")
+)
+
+// buyerRoles may raise, send, receive and close purchase orders.
+var buyerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// OrderLine is a product ordered from a partner. Products are named by
+// unique_name and are created in the branch when first received.
+type OrderLine struct {
+	UniqueName      string
+	ProductName     string
+	MeasurementUnit string
+	Quantity        decimal.Decimal
+	UnitPrice       decimal.Decimal
+	SellingPrice    decimal.Decimal
+}
+
+// OrderRequest describes a new draft purchase order.
+type OrderRequest struct {
+	OrganizationID uuid.UUID
+	BranchUuid     uuid.UUID
+	PartnerID      uuid.UUID
+	UserProfileID  uuid.UUID
+	ExpectedDate   time.Time
+	Comments       string
+	Lines          []OrderLine
+}
+
+// Order is a purchase order with its lines.
+type Order struct {
+	generated.PurchaseOrder
+	Lines []generated.PurchaseOrderLine `json:"lines"`
+}
+
+// DeliveredLine is the quantity of an order line that arrived. UnitPrice
+// overrides the ordered price when it is valid.
+type DeliveredLine struct {
+	LineID    uuid.UUID
+	Quantity  decimal.Decimal
+	UnitPrice decimal.NullDecimal
+}
+
+// DeliveryRequest describes goods received against an order. Close closes
+// the order even if some lines are still short.
+type DeliveryRequest struct {
+	OrderID        uuid.UUID
+	OrganizationID uuid.UUID
+	UserProfileID  uuid.UUID
+	PaymentMethod  string
+	Comments       string
+	Lines          []DeliveredLine
+	Close          bool
+}
+
+// Discrepancy is an order line whose received quantity differs from the
+// ordered quantity. Difference is positive for an over-delivery and
+// negative for an under-delivery.
+type Discrepancy struct {
+	LineID     uuid.UUID       `json:"line_id"`
+	UniqueName string          `json:"unique_name"`
+	Ordered    decimal.Decimal `json:"ordered"`
+	Received   decimal.Decimal `json:"received"`
+	Difference decimal.Decimal `json:"difference"`
+}
+
+// GoodsReceipt is a goods received note with the purchases it recorded and
+// the order as it stands afterwards.
+type GoodsReceipt struct {
+	Note          generated.GoodsReceivedNote   `json:"note"`
+	Lines         []generated.GoodsReceivedLine `json:"lines"`
+	Purchases     []generated.Purchase          `json:"purchases"`
+	Order         Order                         `json:"order"`
+	Discrepancies []Discrepancy                 `json:"discrepancies"`
+}
+
+// Discrepancies returns the lines of an order that were over- or
+// under-delivered so far.
+func Discrepancies(order Order) []Discrepancy {
+	var found []Discrepancy
+	for _, l := range order.Lines {
+		diff := l.ReceivedQuantity.Sub(l.OrderedQuantity)
+		if diff.IsZero() {
+			continue
+		}
+		found = append(found, Discrepancy{
+			LineID:     l.LineID,
+			UniqueName: l.UniqueName,
+			Ordered:    l.OrderedQuantity,
+			Received:   l.ReceivedQuantity,
+			Difference: diff,
+		})
+	}
+	return found
+}
+
+// CreateOrder saves a draft purchase order.
+func (s *Service) CreateOrder(ctx context.Context, req OrderRequest) (Order, error) {
+	if len(req.Lines) == 0 {
+		return Order{}, ErrEmptyOrder
+	}
+	seen := make(map[string]bool, len(req.Lines))
+	for _, l := range req.Lines {
+		if !l.Quantity.IsPositive() {
+			return Order{}, fmt.Errorf("line %s: %w", l.UniqueName, ErrInvalidQuantity)
+		}
+		if seen[l.UniqueName] {
+			return Order{}, fmt.Errorf("product %s: %w", l.UniqueName, ErrDuplicateLine)
+		}
+		seen[l.UniqueName] = true
+	}
+
+	var order Order
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if err := actor.RequireBranchRole(req.BranchUuid, buyerRoles...); err != nil {
+			return err
+		}
+		count, err := q.CountOrganizationBranches(ctx, generated.CountOrganizationBranchesParams{
+			OrganizationID: req.OrganizationID,
+			BranchIds:      []uuid.UUID{req.BranchUuid},
+		})
+		if err != nil {
+			return fmt.Errorf("check order branch: %w", err)
+		}
+		if count != 1 {
+			return ErrUnknownBranch
+		}
+		_, err = q.GetPartner(ctx, generated.GetPartnerParams{
+			PartnerID:      req.PartnerID,
+			OrganizationID: req.OrganizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return partners.ErrPartnerNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get partner: %w", err)
+		}
+
+		order.PurchaseOrder, err = q.InsertPurchaseOrder(ctx, generated.InsertPurchaseOrderParams{
+			PartnerID:      req.PartnerID,
+			BranchUuid:     req.BranchUuid,
+			OrganizationID: req.OrganizationID,
+			CreatedBy:      req.UserProfileID,
+			ExpectedDate:   sql.NullTime{Time: req.ExpectedDate, Valid: !req.ExpectedDate.IsZero()},
+			Comments:       sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("insert purchase order: %w", err)
+		}
+		for _, l := range req.Lines {
+			line, err := q.InsertPurchaseOrderLine(ctx, generated.InsertPurchaseOrderLineParams{
+				OrderID:         order.OrderID,
+				UniqueName:      l.UniqueName,
+				ProductName:     l.ProductName,
+				MeasurementUnit: l.MeasurementUnit,
+				OrderedQuantity: l.Quantity,
+				UnitPrice:       l.UnitPrice,
+				SellingPrice:    l.SellingPrice,
+			})
+			if err != nil {
+				return fmt.Errorf("insert purchase order line %s: %w", l.UniqueName, err)
+			}
+			order.Lines = append(order.Lines, line)
+		}
+		return nil
+	})
+	return order, err
+}
+
+// GetOrder returns a purchase order and its lines.
+func (s *Service) GetOrder(ctx context.Context, orderID, organizationID uuid.UUID) (Order, error) {
+	q := s.db.Queries()
+	po, err := q.GetPurchaseOrder(ctx, generated.GetPurchaseOrderParams{
+		OrderID:        orderID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Order{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return Order{}, fmt.Errorf("get purchase order: %w", err)
+	}
+	lines, err := q.ListPurchaseOrderLines(ctx, orderID)
+	if err != nil {
+		return Order{}, fmt.Errorf("list purchase order lines: %w", err)
+	}
+	return Order{PurchaseOrder: po, Lines: lines}, nil
+}
+
+// ListOrders returns the purchase orders of an organization, newest first,
+// optionally filtered by status and partner.
+func (s *Service) ListOrders(ctx context.Context, organizationID uuid.UUID, status generated.NullPurchaseOrderStatus, partnerID uuid.NullUUID) ([]generated.PurchaseOrder, error) {
+	return s.db.Queries().ListPurchaseOrders(ctx, generated.ListPurchaseOrdersParams{
+		OrganizationID: organizationID,
+		Status:         status,
+		PartnerID:      partnerID,
+	})
+}
+
+// ListGoodsReceivedNotes returns the deliveries recorded against an order.
+func (s *Service) ListGoodsReceivedNotes(ctx context.Context, orderID, organizationID uuid.UUID) ([]generated.GoodsReceivedNote, error) {
+	if _, err := s.GetOrder(ctx, orderID, organizationID); err != nil {
+		return nil, err
+	}
+	return s.db.Queries().ListGoodsReceivedNotes(ctx, orderID)
+}
+
+// SendOrder marks a draft order as sent to the partner.
+func (s *Service) SendOrder(ctx context.Context, orderID, organizationID, userProfileID uuid.UUID) (generated.PurchaseOrder, error) {
+	return s.transition(ctx, orderID, organizationID, userProfileID, generated.PurchaseOrderStatusSent,
+		generated.PurchaseOrderStatusDraft)
+}
+
+// CloseOrder closes an order without waiting for the outstanding quantities.
+func (s *Service) CloseOrder(ctx context.Context, orderID, organizationID, userProfileID uuid.UUID) (generated.PurchaseOrder, error) {
+	return s.transition(ctx, orderID, organizationID, userProfileID, generated.PurchaseOrderStatusClosed,
+		generated.PurchaseOrderStatusDraft, generated.PurchaseOrderStatusSent, generated.PurchaseOrderStatusPartiallyReceived)
+}
+
+// ReceiveGoods records a delivery against a sent order. The delivered lines
+// are booked through the purchase upsert as one purchase group from the
+// order's partner, so stock, cost layers and the partner's balance follow
+// as for any purchase. The order becomes partially_received, or closed once
+// every line has been received in full or req.Close is set. Lines may be
+// over-delivered; the returned discrepancies show every line whose received
+// quantity differs from the ordered quantity.
+func (s *Service) ReceiveGoods(ctx context.Context, req DeliveryRequest) (GoodsReceipt, error) {
+	if len(req.Lines) == 0 {
+		return GoodsReceipt{}, ErrEmptyOrder
+	}
+	var receipt GoodsReceipt
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		po, actor, err := lockOrder(ctx, q, req.OrderID, req.OrganizationID, req.UserProfileID,
+			generated.PurchaseOrderStatusSent, generated.PurchaseOrderStatusPartiallyReceived)
+		if err != nil {
+			return err
+		}
+		lines, err := q.ListPurchaseOrderLines(ctx, po.OrderID)
+		if err != nil {
+			return fmt.Errorf("list purchase order lines: %w", err)
+		}
+		byID := make(map[uuid.UUID]generated.PurchaseOrderLine, len(lines))
+		for _, l := range lines {
+			byID[l.LineID] = l
+		}
+
+		partner, err := q.GetPartner(ctx, generated.GetPartnerParams{
+			PartnerID:      po.PartnerID,
+			OrganizationID: po.OrganizationID,
+		})
+		if err != nil {
+			return fmt.Errorf("get partner: %w", err)
+		}
+
+		purchase := generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams{
+			Supplier:       sql.NullString{String: partner.PartnerName, Valid: true},
+			TotalCost:      decimal.Zero,
+			PaymentMethod:  sql.NullString{String: req.PaymentMethod, Valid: req.PaymentMethod != ""},
+			BranchUuid:     po.BranchUuid,
+			UserEmail:      actor.Email,
+			Comments:       sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+			PartnerID:      uuid.NullUUID{UUID: po.PartnerID, Valid: true},
+			OrganizationID: po.OrganizationID,
+		}
+		prices := make(map[uuid.UUID]decimal.Decimal, len(req.Lines))
+		for _, d := range req.Lines {
+			line, ok := byID[d.LineID]
+			if !ok {
+				return fmt.Errorf("line %s: %w", d.LineID, ErrUnknownOrderLine)
+			}
+			if _, dup := prices[d.LineID]; dup {
+				return fmt.Errorf("line %s: %w", d.LineID, ErrDuplicateLine)
+			}
+			if !d.Quantity.IsPositive() {
+				return fmt.Errorf("line %s: %w", line.UniqueName, ErrInvalidQuantity)
+			}
+			price := line.UnitPrice
+			if d.UnitPrice.Valid {
+				price = d.UnitPrice.Decimal
+			}
+			prices[d.LineID] = price
+			purchase.TotalCost = purchase.TotalCost.Add(price.Mul(d.Quantity))
+			purchase.Column9 = append(purchase.Column9, line.ProductName)
+			purchase.Column10 = append(purchase.Column10, line.UniqueName)
+			purchase.Column11 = append(purchase.Column11, price)
+			purchase.Column12 = append(purchase.Column12, d.Quantity)
+			purchase.Column13 = append(purchase.Column13, line.SellingPrice)
+			purchase.Column14 = append(purchase.Column14, line.MeasurementUnit)
+		}
+
+		receipt.Purchases, err = RecordPurchase(ctx, q, purchase)
+		if err != nil {
+			return err
+		}
+		if len(receipt.Purchases) == 0 {
+			return fmt.Errorf("record purchase: no purchase lines were recorded")
+		}
+
+		receipt.Note, err = q.InsertGoodsReceivedNote(ctx, generated.InsertGoodsReceivedNoteParams{
+			OrderID:         po.OrderID,
+			PurchaseGroupID: receipt.Purchases[0].PurchaseGroupID.UUID,
+			ReceivedBy:      req.UserProfileID,
+			Comments:        sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("insert goods received note: %w", err)
+		}
+		for _, d := range req.Lines {
+			grnLine, err := q.InsertGoodsReceivedLine(ctx, generated.InsertGoodsReceivedLineParams{
+				GrnID:            receipt.Note.GrnID,
+				OrderLineID:      d.LineID,
+				ReceivedQuantity: d.Quantity,
+				UnitPrice:        prices[d.LineID],
+			})
+			if err != nil {
+				return fmt.Errorf("insert goods received line: %w", err)
+			}
+			receipt.Lines = append(receipt.Lines, grnLine)
+			byID[d.LineID], err = q.AddPurchaseOrderLineReceipt(ctx, generated.AddPurchaseOrderLineReceiptParams{
+				Quantity: d.Quantity,
+				LineID:   d.LineID,
+				OrderID:  po.OrderID,
+			})
+			if err != nil {
+				return fmt.Errorf("update purchase order line %s: %w", d.LineID, err)
+			}
+		}
+
+		complete := true
+		for _, l := range lines {
+			l = byID[l.LineID]
+			if l.ReceivedQuantity.LessThan(l.OrderedQuantity) {
+				complete = false
+			}
+			receipt.Order.Lines = append(receipt.Order.Lines, l)
+		}
+		status := generated.PurchaseOrderStatusPartiallyReceived
+		if complete || req.Close {
+			status = generated.PurchaseOrderStatusClosed
+		}
+		receipt.Order.PurchaseOrder, err = q.SetPurchaseOrderStatus(ctx, generated.SetPurchaseOrderStatusParams{
+			Status:  status,
+			OrderID: po.OrderID,
+		})
+		if err != nil {
+			return fmt.Errorf("update purchase order status: %w", err)
+		}
+		receipt.Discrepancies = Discrepancies(receipt.Order)
+		return nil
+	})
+	return receipt, err
+}
+
+// transition moves an order in one of from to status.
+func (s *Service) transition(ctx context.Context, orderID, organizationID, userProfileID uuid.UUID, status generated.PurchaseOrderStatus, from ...generated.PurchaseOrderStatus) (generated.PurchaseOrder, error) {
+	var po generated.PurchaseOrder
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, _, err := lockOrder(ctx, q, orderID, organizationID, userProfileID, from...); err != nil {
+			return err
+		}
+		var err error
+		po, err = q.SetPurchaseOrderStatus(ctx, generated.SetPurchaseOrderStatusParams{
+			Status:  status,
+			OrderID: orderID,
+		})
+		if err != nil {
+			return fmt.Errorf("update purchase order status: %w", err)
+		}
+		return nil
+	})
+	return po, err
+}
+
+// lockOrder locks an order in one of statuses and checks the user may work
+// on it.
+func lockOrder(ctx context.Context, q *generated.Queries, orderID, organizationID, userProfileID uuid.UUID, statuses ...generated.PurchaseOrderStatus) (generated.PurchaseOrder, access.Actor, error) {
+	po, err := q.LockPurchaseOrder(ctx, generated.LockPurchaseOrderParams{
+		OrderID:        orderID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return po, access.Actor{}, ErrOrderNotFound
+	}
+	if err != nil {
+		return po, access.Actor{}, fmt.Errorf("lock purchase order: %w", err)
+	}
+	if !slices.Contains(statuses, po.Status) {
+		return po, access.Actor{}, fmt.Errorf("order %s is %s: %w", orderID, po.Status, ErrOrderStatus)
+	}
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return po, access.Actor{}, err
+	}
+	if err := actor.RequireBranchRole(po.BranchUuid, buyerRoles...); err != nil {
+		return po, access.Actor{}, err
+	}
+	return po, actor, nil
+}
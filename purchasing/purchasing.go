@@ -0,0 +1,113 @@
+// Package purchasing records stock purchases through the product and
+// purchase upsert and keeps the cost layers in step with them.
+package purchasing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/costing"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/payments"
+	"github.com/sushan531/auth-sqlc/store"
+	"github.com/sushan531/auth-sqlc/units"
+)
+
+// ErrCreditWithoutPartner is returned when part of a purchase is on credit
+// but no supplier is named.
+var ErrCreditWithoutPartner = errors.New("purchasing: credit purchases need a supplier")
+
+// Service records purchases.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a purchasing Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// RecordPurchase runs the purchase upsert and adds a cost layer for every
+// purchased line in the same transaction.
+func (s *Service) RecordPurchase(ctx context.Context, arg generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams) ([]generated.Purchase, error) {
+	var purchases []generated.Purchase
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		purchases, err = RecordPurchase(ctx, q, arg)
+		return err
+	})
+	return purchases, err
+}
+
+// RecordSplitPurchase records a purchase like RecordPurchase, settled by
+// tenders that must sum to arg.TotalCost.
+func (s *Service) RecordSplitPurchase(ctx context.Context, arg generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams, tenders []payments.Tender) ([]generated.Purchase, error) {
+	var purchases []generated.Purchase
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		var err error
+		purchases, err = RecordSplitPurchase(ctx, q, arg, tenders)
+		return err
+	})
+	return purchases, err
+}
+
+// RecordPurchase runs the purchase upsert and costing with q, for callers
+// that already hold a transaction. arg.PaymentMethod, when set, settles the
+// whole cost.
+func RecordPurchase(ctx context.Context, q *generated.Queries, arg generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams) ([]generated.Purchase, error) {
+	return RecordSplitPurchase(ctx, q, arg, nil)
+}
+
+// RecordSplitPurchase runs the purchase upsert and costing with q and
+// records the tenders settling the purchase. Without tenders,
+// arg.PaymentMethod settles the whole cost; with neither, the purchase is
+// recorded unpaid. Lines of existing products may be entered in any unit
+// that converts to the product's; their price and units are stored
+// converted. Purchases partly on credit need a PartnerID.
+func RecordSplitPurchase(ctx context.Context, q *generated.Queries, arg generated.InsertOrUpdateProductsWithPurchasesAndPurchaseGroupParams, tenders []payments.Tender) ([]generated.Purchase, error) {
+	if err := units.CheckPurchaseUnits(ctx, q, arg.BranchUuid, arg.Column10, arg.Column14); err != nil {
+		return nil, err
+	}
+	if len(tenders) == 0 {
+		tenders = payments.Single(arg.PaymentMethod.String, arg.TotalCost)
+	}
+	var settlement payments.Settlement
+	if len(tenders) > 0 {
+		var err error
+		settlement, err = payments.Settle(ctx, q, arg.OrganizationID, arg.TotalCost, tenders)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if settlement.Credit.IsPositive() && !arg.PartnerID.Valid {
+		return nil, ErrCreditWithoutPartner
+	}
+	arg.PaymentMethod = settlement.PaymentMethod()
+
+	purchases, err := q.InsertOrUpdateProductsWithPurchasesAndPurchaseGroup(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("insert purchases: %w", err)
+	}
+	if len(purchases) > 0 {
+		_, err := payments.RecordPurchaseTenders(ctx, q, purchases[0].PurchaseGroupID.UUID, settlement)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range purchases {
+		err := costing.Receive(ctx, q, costing.Receipt{
+			ProductID:      p.ProductID.UUID,
+			BranchUuid:     p.BranchUuid,
+			OrganizationID: p.OrganizationID,
+			ReferenceID:    uuid.NullUUID{UUID: p.PurchaseID, Valid: true},
+			Quantity:       p.Units,
+			UnitCost:       p.UnitPurchasePrice,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return purchases, nil
+}
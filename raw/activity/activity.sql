@@ -0,0 +1,30 @@
+-- name: InsertActivity :exec
+INSERT INTO activity (identity, operation, resource, old_value, new_value, status, time, organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, now(), $7);
+
+-- name: ListActivityOrganizations :many
+SELECT DISTINCT organization_id
+FROM activity
+ORDER BY organization_id;
+
+-- name: ListActivityChain :many
+SELECT *
+FROM activity
+WHERE organization_id = $1
+  AND seq > $2
+ORDER BY seq
+LIMIT $3;
+
+-- name: SearchActivity :many
+SELECT *
+FROM activity
+WHERE organization_id = @organization_id
+  AND seq > @after_seq
+  AND (sqlc.narg(identity)::text IS NULL OR identity = sqlc.narg(identity)::text)
+  AND (sqlc.narg(operation)::operation_type IS NULL OR operation = sqlc.narg(operation)::operation_type)
+  AND (sqlc.narg(resource)::text IS NULL OR sqlc.narg(resource)::text = ANY (resource))
+  AND (sqlc.narg(status)::boolean IS NULL OR status = sqlc.narg(status)::boolean)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR time >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR time < sqlc.narg(to_time)::timestamptz)
+ORDER BY seq
+LIMIT @page_size;
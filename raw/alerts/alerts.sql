@@ -0,0 +1,89 @@
+-- name: GetAlertProductBranch :one
+SELECT branch_uuid
+FROM products
+WHERE product_id = @product_id
+  AND organization_id = @organization_id;
+
+
+-- name: UpdateProductReorderLevels :one
+UPDATE products
+SET reorder_point    = @reorder_point,
+    reorder_quantity = @reorder_quantity
+WHERE product_id = @product_id
+  AND organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: ListLowStockProducts :many
+SELECT p.product_id,
+       p.unique_name,
+       p.product_name,
+       p.branch_uuid,
+       b.branch_name,
+       p.remaining_quantity,
+       p.reorder_point,
+       p.reorder_quantity
+FROM products p
+         INNER JOIN branches b ON b.id = p.branch_uuid
+WHERE p.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuid)::uuid IS NULL OR p.branch_uuid = sqlc.narg(branch_uuid)::uuid)
+  AND p.reorder_point > 0
+  AND p.remaining_quantity <= p.reorder_point
+ORDER BY b.branch_name, p.remaining_quantity - p.reorder_point, p.unique_name;
+
+
+-- name: ResolveRecoveredStockAlerts :execrows
+UPDATE stock_alerts a
+SET resolved_at = now()
+FROM products p
+WHERE p.product_id = a.product_id
+  AND a.resolved_at IS NULL
+  AND (p.reorder_point <= 0 OR p.remaining_quantity > p.reorder_point);
+
+
+-- name: RaiseStockAlerts :execrows
+INSERT INTO stock_alerts (product_id, branch_uuid, organization_id, remaining_quantity, reorder_point, reorder_quantity)
+SELECT product_id, branch_uuid, organization_id, remaining_quantity, reorder_point, reorder_quantity
+FROM products
+WHERE reorder_point > 0
+  AND remaining_quantity <= reorder_point
+ON CONFLICT (product_id) WHERE resolved_at IS NULL DO NOTHING;
+
+
+-- name: ListPendingStockAlerts :many
+SELECT a.alert_id,
+       a.product_id,
+       p.unique_name,
+       p.product_name,
+       a.branch_uuid,
+       b.branch_name,
+       a.organization_id,
+       a.remaining_quantity,
+       a.reorder_point,
+       a.reorder_quantity,
+       a.raised_at
+FROM stock_alerts a
+         INNER JOIN products p ON p.product_id = a.product_id
+         INNER JOIN branches b ON b.id = a.branch_uuid
+WHERE a.notified_at IS NULL
+  AND a.resolved_at IS NULL
+ORDER BY a.raised_at;
+
+
+-- name: MarkStockAlertNotified :exec
+UPDATE stock_alerts
+SET notified_at = now()
+WHERE alert_id = $1
+  AND notified_at IS NULL;
+
+
+-- name: ListStockAlertDeliveries :many
+SELECT channel
+FROM stock_alert_deliveries
+WHERE alert_id = $1;
+
+
+-- name: InsertStockAlertDelivery :exec
+INSERT INTO stock_alert_deliveries (alert_id, channel)
+VALUES ($1, $2)
+ON CONFLICT (alert_id, channel) DO NOTHING;
@@ -0,0 +1,112 @@
+-- name: StartBulkLoad :exec
+INSERT INTO bulk_loads (organization_id, load_name, table_name, started_by)
+VALUES (@organization_id, @load_name, @table_name, @started_by)
+ON CONFLICT (organization_id, load_name) DO NOTHING;
+
+
+-- name: GetBulkLoad :one
+SELECT *
+FROM bulk_loads
+WHERE organization_id = @organization_id
+  AND load_name = @load_name;
+
+
+-- name: LockBulkLoad :one
+SELECT *
+FROM bulk_loads
+WHERE organization_id = @organization_id
+  AND load_name = @load_name
+    FOR UPDATE;
+
+
+-- name: AdvanceBulkLoad :one
+UPDATE bulk_loads
+SET rows_read     = rows_read + @rows_loaded::bigint + @rows_rejected::bigint,
+    rows_loaded   = rows_loaded + @rows_loaded::bigint,
+    rows_rejected = rows_rejected + @rows_rejected::bigint,
+    updated_at    = now()
+WHERE organization_id = @organization_id
+  AND load_name = @load_name
+    RETURNING *;
+
+
+-- name: CompleteBulkLoad :one
+UPDATE bulk_loads
+SET completed_at = now(),
+    updated_at   = now()
+WHERE organization_id = @organization_id
+  AND load_name = @load_name
+    RETURNING *;
+
+
+-- name: ListBulkLoadBranches :many
+SELECT id
+FROM branches
+WHERE organization_id = @organization_id
+  AND id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadMembers :many
+SELECT user_profile_id
+FROM user_organization_branch
+WHERE organization_id = @organization_id
+  AND user_profile_id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadCustomers :many
+SELECT partner_id
+FROM partners
+WHERE organization_id = @organization_id
+  AND partner_type IN ('customer', 'both')
+  AND partner_id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadShifts :many
+SELECT shift_id, branch_uuid
+FROM shifts
+WHERE organization_id = @organization_id
+  AND shift_id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadProducts :many
+SELECT product_id, branch_uuid, measurement_unit
+FROM products
+WHERE organization_id = @organization_id
+  AND product_id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadUnitFactors :many
+SELECT t.product_id, t.unit::text AS unit, COALESCE(product_unit_factor(t.product_id, t.unit), 0)::numeric AS factor
+FROM unnest(@product_ids::uuid[], @units::text[]) AS t(product_id, unit);
+
+
+-- name: ListBulkLoadPaymentMethods :many
+SELECT method_id, name, kind
+FROM payment_methods
+WHERE organization_id = @organization_id
+  AND name = ANY (@names::text[]);
+
+
+-- name: ListBulkLoadPurchaseGroups :many
+SELECT purchase_group_id, branch_uuid
+FROM purchase_group
+WHERE organization_id = @organization_id
+  AND purchase_group_id = ANY (@ids::uuid[]);
+
+
+-- name: ListBulkLoadSalesGroups :many
+SELECT sales_group_id, branch_uuid, organization_id
+FROM sales_group
+WHERE sales_group_id = ANY (@ids::uuid[]);
+
+
+-- name: ListExistingPurchaseIDs :many
+SELECT purchase_id
+FROM purchases
+WHERE purchase_id = ANY (@ids::uuid[]);
+
+
+-- name: ListExistingSaleIDs :many
+SELECT sales_id
+FROM sales
+WHERE sales_id = ANY (@ids::uuid[]);
@@ -0,0 +1,93 @@
+-- name: ListProductBranches :many
+SELECT branch_uuid
+FROM products
+WHERE organization_id = @organization_id
+  AND unique_name = @unique_name
+ORDER BY branch_uuid;
+
+
+-- name: GetVariantStanding :one
+SELECT EXISTS (SELECT 1
+               FROM product_variants v
+               WHERE v.organization_id = @organization_id
+                 AND v.unique_name = @unique_name)        AS is_variant,
+       EXISTS (SELECT 1
+               FROM product_variants v
+               WHERE v.organization_id = @organization_id
+                 AND v.parent_unique_name = @unique_name) AS has_variants;
+
+
+-- name: UpsertProductVariant :one
+INSERT INTO product_variants (organization_id, unique_name, parent_unique_name, options)
+VALUES (@organization_id, @unique_name, @parent_unique_name, @options)
+ON CONFLICT (organization_id, unique_name) DO UPDATE
+    SET parent_unique_name = EXCLUDED.parent_unique_name,
+        options            = EXCLUDED.options
+    RETURNING *;
+
+
+-- name: DeleteProductVariant :execrows
+DELETE
+FROM product_variants
+WHERE organization_id = @organization_id
+  AND unique_name = @unique_name;
+
+
+-- name: ListProductVariants :many
+SELECT *
+FROM product_variants
+WHERE organization_id = @organization_id
+  AND parent_unique_name = @parent_unique_name
+ORDER BY unique_name;
+
+
+-- name: InsertProductCode :one
+INSERT INTO product_codes (organization_id, code, code_type, unique_name)
+VALUES (@organization_id, @code, @code_type, @unique_name)
+ON CONFLICT (organization_id, code) DO NOTHING
+    RETURNING *;
+
+
+-- name: GetProductCode :one
+SELECT *
+FROM product_codes
+WHERE organization_id = @organization_id
+  AND code = @code;
+
+
+-- name: DeleteProductCode :execrows
+DELETE
+FROM product_codes
+WHERE organization_id = @organization_id
+  AND code = @code;
+
+
+-- name: ListProductCodes :many
+SELECT *
+FROM product_codes
+WHERE organization_id = @organization_id
+  AND unique_name = @unique_name
+ORDER BY code_type, code;
+
+
+-- name: LookupBranchProductByCode :one
+SELECT p.product_id,
+       p.product_name,
+       p.unique_name,
+       effective_selling_price(p.product_id, now())::numeric AS selling_price,
+       p.remaining_quantity,
+       p.measurement_unit,
+       c.code,
+       c.code_type,
+       v.parent_unique_name,
+       COALESCE(v.options, '{}')::jsonb AS options
+FROM product_codes c
+         INNER JOIN products p
+                    ON p.branch_uuid = @branch_uuid
+                        AND p.unique_name = c.unique_name
+                        AND p.organization_id = c.organization_id
+         LEFT JOIN product_variants v
+                   ON v.organization_id = c.organization_id
+                       AND v.unique_name = c.unique_name
+WHERE c.organization_id = @organization_id
+  AND c.code = @code;
@@ -0,0 +1,99 @@
+-- name: GetOrganizationCostingMethod :one
+SELECT costing_method
+FROM organization
+WHERE id = $1;
+
+
+-- name: SetOrganizationCostingMethod :exec
+UPDATE organization
+SET costing_method = $2
+WHERE id = $1;
+
+
+-- name: InsertCostLayer :one
+INSERT INTO cost_layers (product_id, branch_uuid, organization_id, reference_id, unit_cost, quantity,
+                         remaining_quantity)
+VALUES (@product_id, @branch_uuid, @organization_id, @reference_id, @unit_cost, @quantity, @quantity)
+    RETURNING *;
+
+
+-- name: ApplyReceiptToAverageCost :one
+UPDATE products
+SET average_cost = CASE
+                       WHEN remaining_quantity <= 0 OR remaining_quantity - @quantity::numeric <= 0
+                           THEN @unit_cost::numeric
+                       ELSE ROUND(((remaining_quantity - @quantity::numeric) * average_cost
+                                       + @quantity::numeric * @unit_cost::numeric) / remaining_quantity, 6)
+    END
+WHERE product_id = @product_id
+    RETURNING average_cost;
+
+
+-- name: LockProductCost :one
+SELECT average_cost, remaining_quantity
+FROM products
+WHERE product_id = $1
+    FOR UPDATE;
+
+
+-- name: LockOpenCostLayers :many
+SELECT layer_id, unit_cost, remaining_quantity
+FROM cost_layers
+WHERE product_id = $1
+  AND remaining_quantity > 0
+ORDER BY received_at, layer_id
+    FOR UPDATE;
+
+
+-- name: ConsumeCostLayer :exec
+UPDATE cost_layers
+SET remaining_quantity = remaining_quantity - @quantity::numeric
+WHERE layer_id = @layer_id;
+
+
+-- name: InsertCostAllocation :exec
+INSERT INTO cost_allocations (layer_id, reference_id, quantity, unit_cost)
+VALUES ($1, $2, $3, $4);
+
+
+-- name: ListCostAllocations :many
+SELECT *
+FROM cost_allocations
+WHERE reference_id = $1
+ORDER BY allocation_id;
+
+
+-- name: ListBranchInventoryValuations :many
+SELECT p.branch_uuid,
+       b.branch_name,
+       COUNT(*)                                                 AS product_count,
+       COALESCE(SUM(p.remaining_quantity), 0)::numeric          AS total_quantity,
+       COALESCE(SUM(p.remaining_quantity * p.average_cost), 0)::numeric AS average_cost_value,
+       COALESCE(SUM(l.fifo_value), 0)::numeric                  AS fifo_value
+FROM products p
+         INNER JOIN branches b ON b.id = p.branch_uuid
+         LEFT JOIN (SELECT product_id, SUM(remaining_quantity * unit_cost) AS fifo_value
+                    FROM cost_layers
+                    WHERE remaining_quantity > 0
+                    GROUP BY product_id) l ON l.product_id = p.product_id
+WHERE p.organization_id = $1
+  AND p.remaining_quantity > 0
+GROUP BY p.branch_uuid, b.branch_name
+ORDER BY b.branch_name;
+
+
+-- name: ListProductValuations :many
+SELECT p.product_id,
+       p.unique_name,
+       p.product_name,
+       p.remaining_quantity,
+       p.average_cost,
+       (p.remaining_quantity * p.average_cost)::numeric AS average_cost_value,
+       COALESCE((SELECT SUM(l.remaining_quantity * l.unit_cost)
+                 FROM cost_layers l
+                 WHERE l.product_id = p.product_id
+                   AND l.remaining_quantity > 0), 0)::numeric AS fifo_value
+FROM products p
+WHERE p.organization_id = $1
+  AND p.branch_uuid = $2
+ORDER BY p.unique_name;
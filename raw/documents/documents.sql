@@ -0,0 +1,33 @@
+-- name: NextDocumentNumber :one
+INSERT INTO document_sequences (branch_uuid, document_type, last_number)
+VALUES ($1, $2, 1)
+ON CONFLICT (branch_uuid, document_type) DO UPDATE
+    SET last_number = document_sequences.last_number + 1
+    RETURNING last_number;
+
+
+-- name: InsertSalesDocument :one
+INSERT INTO sales_documents (sales_group_id, branch_uuid, organization_id, document_type, document_number, issued_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING *;
+
+
+-- name: GetSalesDocument :one
+SELECT *
+FROM sales_documents
+WHERE sales_group_id = $1
+  AND document_type = $2;
+
+
+-- name: GetDocumentHeader :one
+SELECT o.name             AS organization_name,
+       o.pan_number       AS organization_pan_number,
+       o.address          AS organization_address,
+       b.unique_name      AS branch_unique_name,
+       b.branch_name,
+       b.address          AS branch_address,
+       b.contact_number   AS branch_contact_number
+FROM branches b
+         INNER JOIN organization o ON o.id = b.organization_id
+WHERE b.id = $1
+  AND b.organization_id = $2;
@@ -0,0 +1,25 @@
+-- name: GetProductImage :one
+SELECT product_id, branch_uuid, product_image
+FROM products
+WHERE product_id = @product_id
+  AND organization_id = @organization_id;
+
+
+-- name: LockProductImage :one
+SELECT product_image
+FROM products
+WHERE product_id = @product_id
+    FOR UPDATE;
+
+
+-- name: UpdateProductImage :one
+UPDATE products
+SET product_image = @product_image
+WHERE product_id = @product_id
+    RETURNING *;
+
+
+-- name: CountProductImageUses :one
+SELECT count(*)
+FROM products
+WHERE product_image = @product_image;
@@ -0,0 +1,52 @@
+-- name: InsertStockMovement :one
+INSERT INTO stock_movements (product_id, branch_uuid, organization_id, movement_type, quantity,
+                             reference_id, user_profile_id, comments)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING *;
+
+
+-- name: ListProductStockMovements :many
+SELECT movement_id,
+       movement_type,
+       quantity,
+       SUM(quantity) OVER (ORDER BY created_at, movement_id)::numeric AS balance,
+       reference_id,
+       user_profile_id,
+       comments,
+       created_at
+FROM stock_movements
+WHERE product_id = $1
+  AND organization_id = $2
+ORDER BY created_at, movement_id;
+
+
+-- name: GetProductLedgerQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::numeric AS ledger_quantity
+FROM stock_movements
+WHERE product_id = $1;
+
+
+-- name: ListStockMismatches :many
+SELECT p.product_id,
+       p.unique_name,
+       p.product_name,
+       p.branch_uuid,
+       p.organization_id,
+       p.remaining_quantity,
+       COALESCE(SUM(sm.quantity), 0)::numeric AS ledger_quantity
+FROM products p
+         LEFT JOIN stock_movements sm ON sm.product_id = p.product_id
+WHERE sqlc.narg(organization_id)::uuid IS NULL
+   OR p.organization_id = sqlc.narg(organization_id)::uuid
+GROUP BY p.product_id
+HAVING p.remaining_quantity <> COALESCE(SUM(sm.quantity), 0)
+ORDER BY p.organization_id, p.branch_uuid, p.unique_name;
+
+
+-- name: ReconcileProductStock :one
+UPDATE products p
+SET remaining_quantity = (SELECT COALESCE(SUM(sm.quantity), 0)
+                          FROM stock_movements sm
+                          WHERE sm.product_id = p.product_id)
+WHERE p.product_id = $1
+    RETURNING p.remaining_quantity;
@@ -0,0 +1,173 @@
+-- name: InsertPartner :one
+INSERT INTO partners (unique_name, partner_name, contact_number, pan_number, address, email, branch_uuid,
+                      organization_id, partner_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    RETURNING *;
+
+
+-- name: GetPartner :one
+SELECT *
+FROM partners
+WHERE partner_id = $1
+  AND organization_id = $2;
+
+
+-- name: ListPartners :many
+SELECT *
+FROM partners
+WHERE organization_id = $1
+ORDER BY partner_name;
+
+
+-- name: ListPartnersByType :many
+SELECT *
+FROM partners
+WHERE organization_id = $1
+  AND partner_type IN ($2, 'both')
+ORDER BY partner_name;
+
+
+-- name: UpdatePartner :one
+UPDATE partners
+SET partner_name   = $3,
+    contact_number = $4,
+    pan_number     = $5,
+    address        = $6,
+    email          = $7,
+    partner_type   = COALESCE($8, partner_type)
+WHERE partner_id = $1
+  AND organization_id = $2
+    RETURNING *;
+
+
+-- name: PartnerInUse :one
+SELECT EXISTS (SELECT 1 FROM purchase_group WHERE partner_id = @partner_id)
+           OR EXISTS (SELECT 1 FROM sales_group WHERE partner_id = @partner_id)
+           OR EXISTS (SELECT 1 FROM partner_payment_receipt WHERE partner_id = @partner_id)
+           OR EXISTS (SELECT 1 FROM purchase_orders WHERE partner_id = @partner_id) AS in_use;
+
+
+-- name: DeletePartner :execrows
+DELETE
+FROM partners
+WHERE partner_id = $1
+  AND organization_id = $2;
+
+
+-- name: InsertPartnerPaymentReceipt :one
+INSERT INTO partner_payment_receipt (partner_id, record_type, amount, branch_uuid, user_profile_id, comments,
+                                     organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING *;
+
+
+-- name: GetPartnerBalance :one
+WITH entries AS (SELECT t.amount
+                 FROM purchase_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN purchase_group pg ON pg.purchase_group_id = t.purchase_group_id
+                 WHERE pg.partner_id = @partner_id
+                   AND pg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                   AND pg.purchase_date::timestamptz < @as_of::timestamptz
+                 UNION ALL
+                 SELECT -t.amount
+                 FROM sales_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN sales_group sg ON sg.sales_group_id = t.sales_group_id
+                 WHERE sg.partner_id = @partner_id
+                   AND sg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                   AND sg.sold_date::timestamptz < @as_of::timestamptz
+                 UNION ALL
+                 SELECT CASE WHEN pr.record_type = 'payment' THEN -pr.amount ELSE pr.amount END
+                 FROM partner_payment_receipt pr
+                 WHERE pr.partner_id = @partner_id
+                   AND pr.organization_id = @organization_id
+                   AND pr.recorded_at < @as_of::timestamptz)
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM entries;
+
+
+-- name: ListPartnerLedgerEntries :many
+WITH entries AS (SELECT pg.purchase_group_id           AS entry_id,
+                        'credit_purchase'              AS entry_type,
+                        pg.purchase_date::timestamptz AS entry_date,
+                        t.amount                       AS amount,
+                        pg.comments
+                 FROM purchase_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN purchase_group pg ON pg.purchase_group_id = t.purchase_group_id
+                 WHERE pg.partner_id = @partner_id
+                   AND pg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                 UNION ALL
+                 SELECT sg.sales_group_id,
+                        'credit_sale',
+                        sg.sold_date::timestamptz,
+                        -t.amount,
+                        sg.comments
+                 FROM sales_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN sales_group sg ON sg.sales_group_id = t.sales_group_id
+                 WHERE sg.partner_id = @partner_id
+                   AND sg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                 UNION ALL
+                 SELECT pr.pr_id,
+                        COALESCE(pr.record_type, 'receipt'),
+                        pr.recorded_at,
+                        CASE WHEN pr.record_type = 'payment' THEN -pr.amount ELSE pr.amount END,
+                        pr.comments
+                 FROM partner_payment_receipt pr
+                 WHERE pr.partner_id = @partner_id
+                   AND pr.organization_id = @organization_id),
+     running AS (SELECT entry_id,
+                        entry_type,
+                        entry_date,
+                        amount,
+                        SUM(amount) OVER (ORDER BY entry_date, entry_id) AS balance,
+                        comments
+                 FROM entries
+                 WHERE entry_date < @to_date::timestamptz)
+SELECT entry_id::uuid             AS entry_id,
+       entry_type::text           AS entry_type,
+       entry_date::timestamptz    AS entry_date,
+       amount::numeric            AS amount,
+       balance::numeric           AS balance,
+       COALESCE(comments, '')::text AS comments
+FROM running
+WHERE entry_date >= @from_date::timestamptz
+ORDER BY entry_date, entry_id;
+
+
+-- name: ListPartnerBalances :many
+WITH entries AS (SELECT pg.partner_id, t.amount
+                 FROM purchase_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN purchase_group pg ON pg.purchase_group_id = t.purchase_group_id
+                 WHERE pg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                   AND pg.partner_id IS NOT NULL
+                 UNION ALL
+                 SELECT sg.partner_id, -t.amount
+                 FROM sales_tenders t
+                          INNER JOIN payment_methods m ON m.method_id = t.method_id
+                          INNER JOIN sales_group sg ON sg.sales_group_id = t.sales_group_id
+                 WHERE sg.organization_id = @organization_id
+                   AND m.kind = 'credit'
+                   AND sg.partner_id IS NOT NULL
+                 UNION ALL
+                 SELECT pr.partner_id, CASE WHEN pr.record_type = 'payment' THEN -pr.amount ELSE pr.amount END
+                 FROM partner_payment_receipt pr
+                 WHERE pr.organization_id = @organization_id)
+SELECT p.partner_id,
+       p.unique_name,
+       p.partner_name,
+       p.partner_type,
+       COALESCE(SUM(e.amount), 0)::numeric AS balance
+FROM partners p
+         LEFT JOIN entries e ON e.partner_id = p.partner_id
+WHERE p.organization_id = @organization_id
+GROUP BY p.partner_id, p.unique_name, p.partner_name, p.partner_type
+ORDER BY p.partner_name;
@@ -0,0 +1,57 @@
+-- name: InsertPaymentMethod :one
+INSERT INTO payment_methods (organization_id, name, kind)
+VALUES (@organization_id, @name, @kind)
+    RETURNING *;
+
+
+-- name: SetPaymentMethodActive :one
+UPDATE payment_methods
+SET is_active = @is_active
+WHERE method_id = @method_id
+  AND organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: ListPaymentMethods :many
+SELECT *
+FROM payment_methods
+WHERE organization_id = @organization_id
+ORDER BY name;
+
+
+-- name: InsertSalesTender :one
+INSERT INTO sales_tenders (sales_group_id, method_id, amount, tendered, change_due)
+VALUES (@sales_group_id, @method_id, @amount, @tendered, @change_due)
+    RETURNING *;
+
+
+-- name: InsertPurchaseTender :one
+INSERT INTO purchase_tenders (purchase_group_id, method_id, amount)
+VALUES (@purchase_group_id, @method_id, @amount)
+    RETURNING *;
+
+
+-- name: ListSalesTenders :many
+SELECT t.tender_id,
+       t.method_id,
+       m.name,
+       m.kind,
+       t.amount,
+       t.tendered,
+       t.change_due
+FROM sales_tenders t
+         INNER JOIN payment_methods m ON m.method_id = t.method_id
+WHERE t.sales_group_id = @sales_group_id
+ORDER BY t.tender_id;
+
+
+-- name: ListPurchaseTenders :many
+SELECT t.tender_id,
+       t.method_id,
+       m.name,
+       m.kind,
+       t.amount
+FROM purchase_tenders t
+         INNER JOIN payment_methods m ON m.method_id = t.method_id
+WHERE t.purchase_group_id = @purchase_group_id
+ORDER BY t.tender_id;
@@ -0,0 +1,61 @@
+-- name: UpdateProductSellingPrice :one
+UPDATE products
+SET selling_price = @selling_price
+WHERE product_id = @product_id
+  AND organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: GetPricedProduct :one
+SELECT product_id,
+       branch_uuid,
+       selling_price,
+       effective_selling_price(product_id, now())::numeric AS effective_price
+FROM products
+WHERE product_id = @product_id
+  AND organization_id = @organization_id;
+
+
+-- name: InsertProductPrice :one
+INSERT INTO product_prices (product_id, branch_uuid, organization_id, kind, selling_price, starts_at, ends_at,
+                            created_by)
+SELECT p.product_id, p.branch_uuid, p.organization_id, @kind, @selling_price, @starts_at, @ends_at, @created_by
+FROM products p
+WHERE p.product_id = @product_id
+  AND p.organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: GetProductPrice :one
+SELECT *
+FROM product_prices
+WHERE price_id = @price_id
+  AND organization_id = @organization_id;
+
+
+-- name: ListProductPrices :many
+SELECT *
+FROM product_prices
+WHERE product_id = @product_id
+  AND organization_id = @organization_id
+ORDER BY starts_at DESC, price_id DESC;
+
+
+-- name: DeleteScheduledProductPrice :execrows
+DELETE
+FROM product_prices
+WHERE price_id = @price_id
+  AND starts_at > now();
+
+
+-- name: EndProductPromotion :execrows
+UPDATE product_prices
+SET ends_at = now()
+WHERE price_id = @price_id
+  AND kind = 'promotion'
+  AND starts_at < now()
+  AND ends_at > now();
+
+
+-- name: GetEffectiveSellingPrice :one
+SELECT effective_selling_price(@product_id, @at)::numeric AS selling_price;
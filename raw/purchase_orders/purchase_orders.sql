@@ -0,0 +1,78 @@
+-- name: InsertPurchaseOrder :one
+INSERT INTO purchase_orders (partner_id, branch_uuid, organization_id, created_by, expected_date, comments)
+VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING *;
+
+
+-- name: InsertPurchaseOrderLine :one
+INSERT INTO purchase_order_lines (order_id, unique_name, product_name, measurement_unit, ordered_quantity,
+                                  unit_price, selling_price)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING *;
+
+
+-- name: GetPurchaseOrder :one
+SELECT *
+FROM purchase_orders
+WHERE order_id = $1
+  AND organization_id = $2;
+
+
+-- name: LockPurchaseOrder :one
+SELECT *
+FROM purchase_orders
+WHERE order_id = $1
+  AND organization_id = $2
+    FOR UPDATE;
+
+
+-- name: ListPurchaseOrders :many
+SELECT *
+FROM purchase_orders
+WHERE organization_id = @organization_id
+  AND (sqlc.narg(status)::purchase_order_status IS NULL OR status = sqlc.narg(status)::purchase_order_status)
+  AND (sqlc.narg(partner_id)::uuid IS NULL OR partner_id = sqlc.narg(partner_id)::uuid)
+ORDER BY created_at DESC;
+
+
+-- name: ListPurchaseOrderLines :many
+SELECT *
+FROM purchase_order_lines
+WHERE order_id = $1
+ORDER BY line_id;
+
+
+-- name: SetPurchaseOrderStatus :one
+UPDATE purchase_orders
+SET status    = @status::purchase_order_status,
+    sent_at   = CASE WHEN @status::purchase_order_status = 'sent' THEN now() ELSE sent_at END,
+    closed_at = CASE WHEN @status::purchase_order_status = 'closed' THEN now() ELSE closed_at END
+WHERE order_id = @order_id
+    RETURNING *;
+
+
+-- name: AddPurchaseOrderLineReceipt :one
+UPDATE purchase_order_lines
+SET received_quantity = received_quantity + @quantity::numeric
+WHERE line_id = @line_id
+  AND order_id = @order_id
+    RETURNING *;
+
+
+-- name: InsertGoodsReceivedNote :one
+INSERT INTO goods_received_notes (order_id, purchase_group_id, received_by, comments)
+VALUES ($1, $2, $3, $4)
+    RETURNING *;
+
+
+-- name: InsertGoodsReceivedLine :one
+INSERT INTO goods_received_lines (grn_id, order_line_id, received_quantity, unit_price)
+VALUES ($1, $2, $3, $4)
+    RETURNING *;
+
+
+-- name: ListGoodsReceivedNotes :many
+SELECT *
+FROM goods_received_notes
+WHERE order_id = $1
+ORDER BY received_at, grn_id;
@@ -0,0 +1,72 @@
+-- name: InsertOrUpdateProductsWithPurchasesAndPurchaseGroup :many
+WITH purchase_group_insert AS (
+INSERT INTO purchase_group (supplier, total_cost, payment_method, branch_uuid, user_profile_id, comments,
+                            partner_id, organization_id)
+VALUES ($1, $2, $3, $4, (SELECT user_profile_id FROM auth WHERE user_email = $5), $6, $7, $8)
+    RETURNING purchase_group_id, branch_uuid, user_profile_id, organization_id
+),
+     purchase_input AS (
+         SELECT product_name, unique_name, unit_purchase_price, units, selling_price, measurement_unit
+         FROM unnest($9::TEXT[], $10::TEXT[], $11::NUMERIC[], $12::NUMERIC[], $13::NUMERIC[], $14::TEXT[])
+                  AS t(product_name, unique_name, unit_purchase_price, units, selling_price, measurement_unit)
+     ),
+     product_upsert AS (
+INSERT INTO products (product_name, unique_name, selling_price, remaining_quantity, branch_uuid,
+                      measurement_unit, organization_id)
+SELECT pi.product_name, pi.unique_name, pi.selling_price, 0, $4, pi.measurement_unit, $8
+FROM purchase_input pi
+ON CONFLICT (branch_uuid, unique_name) DO UPDATE
+    -- A restock keeps the product's selling price: the line's price may be
+    -- per another unit, and prices change through the pricing service.
+    SET product_name = EXCLUDED.product_name
+    RETURNING product_id, unique_name, measurement_unit
+),
+     purchase_units AS (
+         -- Lines entered in another unit than the product's are converted
+         -- to it; new products take the unit of their line.
+         SELECT pu.product_id,
+                pi.product_name,
+                pi.unit_purchase_price,
+                pi.units,
+                pi.measurement_unit,
+                CASE
+                    WHEN pi.measurement_unit = pu.measurement_unit THEN 1
+                    ELSE product_unit_factor(pu.product_id, pi.measurement_unit)
+                    END AS factor
+         FROM purchase_input pi
+                  INNER JOIN product_upsert pu ON pu.unique_name = pi.unique_name
+     ),
+     purchase_insert AS (
+INSERT INTO purchases (purchase_group_id, product_id, product_name, unit_purchase_price, units, branch_uuid,
+                       organization_id, purchased_unit, purchased_quantity)
+SELECT pg.purchase_group_id, pu.product_id, pu.product_name, pu.unit_purchase_price / pu.factor,
+       pu.units * pu.factor, pg.branch_uuid, pg.organization_id, pu.measurement_unit, pu.units
+FROM purchase_units pu
+         CROSS JOIN purchase_group_insert pg
+    RETURNING *
+),
+     movement_insert AS (
+INSERT INTO stock_movements (product_id, branch_uuid, organization_id, movement_type, quantity,
+                             reference_id, user_profile_id)
+SELECT pi.product_id, pi.branch_uuid, pi.organization_id, 'purchase', pi.units, pi.purchase_id,
+       pg.user_profile_id
+FROM purchase_insert pi
+         CROSS JOIN purchase_group_insert pg
+    RETURNING movement_id
+)
+SELECT *
+FROM purchase_insert;
+
+
+-- name: ListUnconvertiblePurchaseUnits :many
+SELECT p.unique_name, t.unit::text AS unit
+FROM unnest(@unique_names::TEXT[], @units::TEXT[]) AS t(unique_name, unit)
+         INNER JOIN products p ON p.unique_name = t.unique_name AND p.branch_uuid = @branch_uuid
+WHERE COALESCE(product_unit_factor(p.product_id, t.unit), 0) = 0;
+
+
+-- name: ListBranchProductUnits :many
+SELECT unique_name, measurement_unit
+FROM products
+WHERE branch_uuid = @branch_uuid
+  AND unique_name = ANY (@unique_names::text[]);
@@ -0,0 +1,157 @@
+-- name: ListSalesByPeriod :many
+SELECT date_trunc(@period::text, sg.sold_date::timestamptz, @time_zone::text)::timestamptz AS period_start,
+       sg.branch_uuid,
+       b.branch_name,
+       COUNT(*)                       AS sales_count,
+       SUM(sg.total_amount)::numeric  AS revenue,
+       SUM(sg.total_profit)::numeric  AS profit
+FROM sales_group sg
+         INNER JOIN branches b ON b.id = sg.branch_uuid
+WHERE sg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR sg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND sg.sold_date::timestamptz >= @from_time::timestamptz
+  AND sg.sold_date::timestamptz < @to_time::timestamptz
+GROUP BY period_start, sg.branch_uuid, b.branch_name
+ORDER BY period_start, b.branch_name;
+
+
+-- name: ListTopSellingProducts :many
+SELECT s.product_id,
+       p.unique_name,
+       p.product_name,
+       sg.branch_uuid,
+       SUM(s.quantity)::numeric AS quantity_sold,
+       SUM(s.total)::numeric    AS revenue,
+       SUM(s.profit)::numeric   AS profit
+FROM sales s
+         INNER JOIN sales_group sg ON sg.sales_group_id = s.sales_group_id
+         INNER JOIN products p ON p.product_id = s.product_id
+WHERE sg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR sg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND sg.sold_date::timestamptz >= @from_time::timestamptz
+  AND sg.sold_date::timestamptz < @to_time::timestamptz
+GROUP BY s.product_id, p.unique_name, p.product_name, sg.branch_uuid
+ORDER BY quantity_sold DESC, revenue DESC, p.unique_name
+LIMIT @row_limit;
+
+
+-- name: ListSalesByPaymentMethod :many
+SELECT COALESCE(m.name, sg.payment_method, '')::text    AS payment_method,
+       COUNT(DISTINCT sg.sales_group_id)                AS sales_count,
+       SUM(COALESCE(t.amount, sg.total_amount))::numeric AS revenue,
+       SUM(CASE
+               WHEN t.tender_id IS NULL THEN sg.total_profit
+               ELSE COALESCE(sg.total_profit * t.amount / NULLIF(sg.total_amount, 0), 0)
+           END)::numeric                                AS profit
+FROM sales_group sg
+         LEFT JOIN sales_tenders t ON t.sales_group_id = sg.sales_group_id
+         LEFT JOIN payment_methods m ON m.method_id = t.method_id
+WHERE sg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR sg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND sg.sold_date::timestamptz >= @from_time::timestamptz
+  AND sg.sold_date::timestamptz < @to_time::timestamptz
+GROUP BY 1
+ORDER BY revenue DESC;
+
+
+-- name: ListCashierPerformance :many
+SELECT sg.user_profile_id,
+       up.full_name,
+       COUNT(*)                      AS sales_count,
+       SUM(sg.total_amount)::numeric AS revenue,
+       SUM(sg.total_profit)::numeric AS profit
+FROM sales_group sg
+         INNER JOIN user_profile up ON up.id = sg.user_profile_id
+WHERE sg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR sg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND sg.sold_date::timestamptz >= @from_time::timestamptz
+  AND sg.sold_date::timestamptz < @to_time::timestamptz
+GROUP BY sg.user_profile_id, up.full_name
+ORDER BY revenue DESC;
+
+
+-- name: ListPurchaseSpendBySupplier :many
+SELECT pg.partner_id,
+       COALESCE(pt.partner_name, pg.supplier, '')::text AS supplier_name,
+       COUNT(*)                                         AS purchase_count,
+       SUM(pg.total_cost)::numeric                      AS total_cost
+FROM purchase_group pg
+         LEFT JOIN partners pt ON pt.partner_id = pg.partner_id
+WHERE pg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR pg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND pg.purchase_date::timestamptz >= @from_time::timestamptz
+  AND pg.purchase_date::timestamptz < @to_time::timestamptz
+GROUP BY pg.partner_id, 2
+ORDER BY total_cost DESC;
+
+
+-- name: ListPurchaseSpendByPeriod :many
+SELECT date_trunc(@period::text, pg.purchase_date::timestamptz, @time_zone::text)::timestamptz AS period_start,
+       pg.branch_uuid,
+       b.branch_name,
+       COUNT(*)                    AS purchase_count,
+       SUM(pg.total_cost)::numeric AS total_cost
+FROM purchase_group pg
+         INNER JOIN branches b ON b.id = pg.branch_uuid
+WHERE pg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR pg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND pg.purchase_date::timestamptz >= @from_time::timestamptz
+  AND pg.purchase_date::timestamptz < @to_time::timestamptz
+GROUP BY period_start, pg.branch_uuid, b.branch_name
+ORDER BY period_start, b.branch_name;
+
+
+-- name: ListProductPurchasePriceTrend :many
+SELECT date_trunc(@period::text, pg.purchase_date::timestamptz, @time_zone::text)::timestamptz AS period_start,
+       SUM(pu.units)::numeric                                                           AS units,
+       COALESCE(ROUND(SUM(pu.units * pu.unit_purchase_price) / NULLIF(SUM(pu.units), 0), 6), 0)::numeric AS average_unit_price,
+       MIN(pu.unit_purchase_price)::numeric                                             AS min_unit_price,
+       MAX(pu.unit_purchase_price)::numeric                                             AS max_unit_price
+FROM purchases pu
+         INNER JOIN purchase_group pg ON pg.purchase_group_id = pu.purchase_group_id
+WHERE pu.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR pu.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND pu.product_id = @product_id
+  AND pg.purchase_date::timestamptz >= @from_time::timestamptz
+  AND pg.purchase_date::timestamptz < @to_time::timestamptz
+GROUP BY period_start
+ORDER BY period_start;
+
+
+-- name: ListNegativeMarginProducts :many
+SELECT p.product_id,
+       p.unique_name,
+       p.product_name,
+       p.branch_uuid,
+       ep.selling_price,
+       p.average_cost,
+       COALESCE(lp.unit_purchase_price, 0)::numeric AS last_purchase_price,
+       (ep.selling_price - GREATEST(p.average_cost, COALESCE(lp.unit_purchase_price, 0)))::numeric AS margin
+FROM products p
+         CROSS JOIN LATERAL (SELECT effective_selling_price(p.product_id, now())::numeric AS selling_price) ep
+         LEFT JOIN LATERAL (SELECT pu.unit_purchase_price
+                            FROM purchases pu
+                                     INNER JOIN purchase_group pg ON pg.purchase_group_id = pu.purchase_group_id
+                            WHERE pu.product_id = p.product_id
+                            ORDER BY pg.purchase_date DESC, pu.purchase_id DESC
+                            LIMIT 1) lp ON TRUE
+WHERE p.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR p.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND (p.average_cost > ep.selling_price OR lp.unit_purchase_price > ep.selling_price)
+ORDER BY margin, p.unique_name;
+
+
+-- name: ListTaxSummary :many
+SELECT s.tax_rate,
+       COUNT(DISTINCT s.sales_group_id) AS sales_count,
+       SUM(s.taxable_amount)::numeric   AS taxable_amount,
+       SUM(s.tax_amount)::numeric       AS tax_amount,
+       SUM(s.total)::numeric            AS total
+FROM sales s
+         INNER JOIN sales_group sg ON sg.sales_group_id = s.sales_group_id
+WHERE sg.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR sg.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND sg.sold_date::timestamptz >= @from_time::timestamptz
+  AND sg.sold_date::timestamptz < @to_time::timestamptz
+GROUP BY s.tax_rate
+ORDER BY s.tax_rate;
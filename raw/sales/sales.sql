@@ -0,0 +1,60 @@
+-- name: LockBranchProducts :many
+SELECT p.product_id,
+       p.unique_name,
+       p.product_name,
+       effective_selling_price(p.product_id, now())::numeric AS selling_price,
+       p.remaining_quantity,
+       p.measurement_unit,
+       COALESCE(t.rate, d.rate, 0)::numeric AS tax_rate
+FROM products p
+         LEFT JOIN tax_rates t ON t.tax_rate_id = p.tax_rate_id
+         LEFT JOIN tax_rates d ON d.organization_id = p.organization_id AND d.is_default
+WHERE p.organization_id = @organization_id
+  AND p.branch_uuid = @branch_uuid
+  AND p.product_id = ANY (@product_ids::uuid[])
+ORDER BY p.product_id
+    FOR UPDATE OF p;
+
+
+-- name: InsertSalesGroup :one
+INSERT INTO sales_group (total_amount, total_profit, payment_method, sold_date, branch_uuid, user_profile_id,
+                         organization_id, customer_name, comments, price_mode, taxable_amount, tax_amount,
+                         partner_id, shift_id)
+VALUES ($1, $2, $3, now(), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    RETURNING *;
+
+
+-- name: InsertSale :one
+INSERT INTO sales (sales_id, sales_group_id, product_id, quantity, current_cost_price, sales_price, total, profit,
+                   tax_rate, taxable_amount, tax_amount, sold_unit, sold_quantity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    RETURNING *;
+
+
+-- name: GetSalesGroup :one
+SELECT *
+FROM sales_group
+WHERE sales_group_id = $1
+  AND organization_id = $2;
+
+
+-- name: ListSalesGroupLines :many
+SELECT s.sales_id,
+       s.product_id,
+       p.unique_name,
+       p.product_name,
+       p.measurement_unit,
+       s.quantity,
+       s.current_cost_price,
+       s.sales_price,
+       s.total,
+       s.profit,
+       s.tax_rate,
+       s.taxable_amount,
+       s.tax_amount,
+       s.sold_unit,
+       s.sold_quantity
+FROM sales s
+         INNER JOIN products p ON p.product_id = s.product_id
+WHERE s.sales_group_id = $1
+ORDER BY s.sales_id;
@@ -0,0 +1,34 @@
+-- name: SearchProducts :many
+WITH search AS (SELECT to_tsquery('simple', @prefix_query::text) AS query, @term::text AS term)
+SELECT p.product_id,
+       p.product_name,
+       p.unique_name,
+       p.description,
+       p.branch_uuid,
+       b.branch_name,
+       effective_selling_price(p.product_id, now())::numeric AS selling_price,
+       p.remaining_quantity,
+       p.measurement_unit,
+       (ts_rank(product_search_document(p.product_name, p.unique_name, p.description), s.query) +
+        greatest(word_similarity(s.term, p.product_name),
+                 word_similarity(s.term, p.unique_name),
+                 word_similarity(s.term, coalesce(p.description, '')) / 2))::float8 AS rank,
+       -- Matches are marked with the control characters STX and ETX,
+       -- removed from the text first, so the caller can escape the text
+       -- before turning them into HTML.
+       ts_headline('simple', translate(p.product_name, chr(2) || chr(3), ''), s.query,
+                   'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', HighlightAll=true') AS name_highlight,
+       ts_headline('simple', translate(coalesce(p.description, ''), chr(2) || chr(3), ''), s.query,
+                   'StartSel=' || chr(2) || ', StopSel=' || chr(3) ||
+                   ', MaxFragments=2, MaxWords=15, MinWords=5') AS description_snippet
+FROM products p
+         CROSS JOIN search s
+         INNER JOIN branches b ON b.id = p.branch_uuid
+WHERE p.organization_id = @organization_id
+  AND (sqlc.narg(branch_uuids)::uuid[] IS NULL OR p.branch_uuid = ANY (sqlc.narg(branch_uuids)::uuid[]))
+  AND (product_search_document(p.product_name, p.unique_name, p.description) @@ s.query
+    OR s.term <% p.product_name
+    OR s.term <% p.unique_name
+    OR s.term <% p.description)
+ORDER BY rank DESC, p.product_name, p.product_id
+LIMIT @page_size OFFSET @page_offset;
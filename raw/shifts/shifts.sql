@@ -0,0 +1,83 @@
+-- name: OpenShift :one
+INSERT INTO shifts (branch_uuid, organization_id, user_profile_id, opening_float, comments)
+VALUES (@branch_uuid, @organization_id, @user_profile_id, @opening_float, @comments)
+    RETURNING *;
+
+
+-- name: GetOpenShift :one
+SELECT *
+FROM shifts
+WHERE user_profile_id = @user_profile_id
+  AND branch_uuid = @branch_uuid
+  AND status = 'open';
+
+
+-- name: ShareOpenShift :one
+SELECT shift_id
+FROM shifts
+WHERE user_profile_id = @user_profile_id
+  AND branch_uuid = @branch_uuid
+  AND status = 'open'
+    FOR SHARE;
+
+
+-- name: GetShift :one
+SELECT *
+FROM shifts
+WHERE shift_id = @shift_id
+  AND organization_id = @organization_id;
+
+
+-- name: LockShift :one
+SELECT *
+FROM shifts
+WHERE shift_id = @shift_id
+  AND organization_id = @organization_id
+    FOR UPDATE;
+
+
+-- name: CloseShift :one
+UPDATE shifts
+SET status        = 'closed',
+    expected_cash = @expected_cash,
+    counted_cash  = @counted_cash,
+    closed_at     = now(),
+    closed_by     = @closed_by,
+    comments      = NULLIF(concat_ws(E'\n', comments, sqlc.narg(comments)::text), '')
+WHERE shift_id = @shift_id
+  AND status = 'open'
+    RETURNING *;
+
+
+-- name: ListBranchShifts :many
+SELECT *
+FROM shifts
+WHERE organization_id = @organization_id
+  AND branch_uuid = @branch_uuid
+  AND opened_at >= @from_time::timestamptz
+  AND opened_at < @to_time::timestamptz
+ORDER BY opened_at;
+
+
+-- name: GetShiftSalesTotals :one
+SELECT COUNT(*)                                     AS sales_count,
+       COALESCE(SUM(sg.total_amount), 0)::numeric   AS total_amount,
+       COALESCE(SUM(sg.taxable_amount), 0)::numeric AS taxable_amount,
+       COALESCE(SUM(sg.tax_amount), 0)::numeric     AS tax_amount
+FROM sales_group sg
+WHERE sg.shift_id = @shift_id;
+
+
+-- name: ListShiftTenders :many
+SELECT m.name,
+       m.kind,
+       COUNT(*)                   AS tender_count,
+       SUM(t.amount)::numeric     AS amount,
+       SUM(t.tendered)::numeric   AS tendered,
+       SUM(t.change_due)::numeric AS change_due
+FROM sales_tenders t
+         INNER JOIN payment_methods m ON m.method_id = t.method_id
+         INNER JOIN sales_group sg ON sg.sales_group_id = t.sales_group_id
+WHERE sg.shift_id = @shift_id
+GROUP BY m.name, m.kind
+ORDER BY m.name;
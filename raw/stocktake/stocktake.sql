@@ -0,0 +1,94 @@
+-- name: InsertStocktakeSession :one
+INSERT INTO stocktake_sessions (branch_uuid, organization_id, opened_by, comments)
+VALUES ($1, $2, $3, $4)
+    RETURNING *;
+
+
+-- name: GetStocktakeSession :one
+SELECT *
+FROM stocktake_sessions
+WHERE session_id = $1
+  AND organization_id = $2;
+
+
+-- name: LockStocktakeSession :one
+SELECT *
+FROM stocktake_sessions
+WHERE session_id = $1
+  AND organization_id = $2
+    FOR UPDATE;
+
+
+-- name: ListStocktakeSessions :many
+SELECT *
+FROM stocktake_sessions
+WHERE organization_id = $1
+  AND branch_uuid = $2
+ORDER BY opened_at DESC;
+
+
+-- name: UpsertStocktakeCount :one
+INSERT INTO stocktake_counts (session_id, product_id, counted_quantity, reason, counted_by, expected_quantity)
+SELECT s.session_id, p.product_id, @counted_quantity::numeric, sqlc.narg(reason)::text, @counted_by::uuid,
+       p.remaining_quantity
+FROM stocktake_sessions s
+         INNER JOIN products p ON p.branch_uuid = s.branch_uuid
+WHERE s.session_id = @session_id
+  AND s.status = 'open'
+  AND p.product_id = @product_id
+ON CONFLICT (session_id, product_id) DO UPDATE
+    SET counted_quantity  = EXCLUDED.counted_quantity,
+        reason            = EXCLUDED.reason,
+        counted_by        = EXCLUDED.counted_by,
+        counted_at        = now(),
+        expected_quantity = EXCLUDED.expected_quantity
+    RETURNING *;
+
+
+-- name: ListStocktakeVariances :many
+SELECT c.count_id,
+       c.product_id,
+       p.unique_name,
+       p.product_name,
+       p.measurement_unit,
+       c.expected_quantity,
+       c.counted_quantity,
+       (c.counted_quantity - c.expected_quantity)::numeric AS variance,
+       c.reason
+FROM stocktake_counts c
+         INNER JOIN products p ON p.product_id = c.product_id
+WHERE c.session_id = $1
+ORDER BY p.unique_name;
+
+
+-- name: LockStocktakeVariances :many
+SELECT c.count_id,
+       c.product_id,
+       p.unique_name,
+       c.expected_quantity,
+       c.counted_quantity,
+       (c.counted_quantity - c.expected_quantity)::numeric AS variance,
+       c.reason
+FROM stocktake_counts c
+         INNER JOIN products p ON p.product_id = c.product_id
+WHERE c.session_id = $1
+ORDER BY p.product_id
+    FOR UPDATE OF p;
+
+
+-- name: PostStocktakeSession :one
+UPDATE stocktake_sessions
+SET status    = 'posted',
+    posted_by = $2,
+    posted_at = now()
+WHERE session_id = $1
+  AND status = 'open'
+    RETURNING *;
+
+
+-- name: CancelStocktakeSession :one
+UPDATE stocktake_sessions
+SET status = 'cancelled'
+WHERE session_id = $1
+  AND status = 'open'
+    RETURNING *;
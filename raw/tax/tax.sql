@@ -0,0 +1,66 @@
+-- name: InsertTaxRate :one
+INSERT INTO tax_rates (organization_id, name, rate)
+VALUES (@organization_id, @name, @rate)
+    RETURNING *;
+
+
+-- name: UpdateTaxRate :one
+UPDATE tax_rates
+SET name = @name,
+    rate = @rate
+WHERE tax_rate_id = @tax_rate_id
+  AND organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: ListTaxRates :many
+SELECT *
+FROM tax_rates
+WHERE organization_id = @organization_id
+ORDER BY name;
+
+
+-- name: ClearDefaultTaxRate :exec
+UPDATE tax_rates
+SET is_default = false
+WHERE organization_id = @organization_id
+  AND is_default;
+
+
+-- name: SetDefaultTaxRate :one
+UPDATE tax_rates
+SET is_default = true
+WHERE tax_rate_id = @tax_rate_id
+  AND organization_id = @organization_id
+    RETURNING *;
+
+
+-- name: GetTaxProductBranch :one
+SELECT branch_uuid
+FROM products
+WHERE product_id = @product_id
+  AND organization_id = @organization_id;
+
+
+-- name: SetProductTaxRate :one
+UPDATE products
+SET tax_rate_id = sqlc.narg(tax_rate_id)
+WHERE product_id = @product_id
+  AND organization_id = @organization_id
+  AND (sqlc.narg(tax_rate_id)::uuid IS NULL OR EXISTS (SELECT 1
+                                                       FROM tax_rates t
+                                                       WHERE t.tax_rate_id = sqlc.narg(tax_rate_id)
+                                                         AND t.organization_id = @organization_id))
+    RETURNING *;
+
+
+-- name: GetOrganizationPriceMode :one
+SELECT price_mode
+FROM organization
+WHERE id = $1;
+
+
+-- name: SetOrganizationPriceMode :exec
+UPDATE organization
+SET price_mode = $2
+WHERE id = $1;
@@ -0,0 +1,124 @@
+-- name: InsertStockTransfer :one
+INSERT INTO stock_transfers (source_branch_uuid, destination_branch_uuid, organization_id, created_by, comments)
+VALUES ($1, $2, $3, $4, $5)
+    RETURNING *;
+
+
+-- name: InsertStockTransferLine :one
+INSERT INTO stock_transfer_lines (transfer_id, source_product_id, quantity)
+SELECT st.transfer_id, p.product_id, @quantity::numeric
+FROM products p
+         INNER JOIN stock_transfers st ON st.source_branch_uuid = p.branch_uuid
+WHERE st.transfer_id = @transfer_id
+  AND p.product_id = @product_id
+    RETURNING *;
+
+
+-- name: GetStockTransfer :one
+SELECT *
+FROM stock_transfers
+WHERE transfer_id = $1
+  AND organization_id = $2;
+
+
+-- name: LockStockTransfer :one
+SELECT *
+FROM stock_transfers
+WHERE transfer_id = $1
+  AND organization_id = $2
+    FOR UPDATE;
+
+
+-- name: ListStockTransfers :many
+SELECT *
+FROM stock_transfers
+WHERE organization_id = @organization_id
+  AND (sqlc.narg(status)::transfer_status IS NULL OR status = sqlc.narg(status)::transfer_status)
+ORDER BY created_at DESC;
+
+
+-- name: ListStockTransferLines :many
+SELECT *
+FROM stock_transfer_lines
+WHERE transfer_id = $1
+ORDER BY line_id;
+
+
+-- name: CountOrganizationBranches :one
+SELECT COUNT(*)
+FROM branches
+WHERE organization_id = @organization_id
+  AND id = ANY (@branch_ids::uuid[]);
+
+
+-- name: LockTransferSourceProduct :one
+SELECT product_id, unique_name, remaining_quantity
+FROM products
+WHERE product_id = $1
+    FOR UPDATE;
+
+
+-- name: UpsertTransferDestinationProduct :one
+INSERT INTO products (product_name, unique_name, product_image, description, selling_price, remaining_quantity,
+                      branch_uuid, measurement_unit, organization_id)
+SELECT p.product_name,
+       p.unique_name,
+       p.product_image,
+       p.description,
+       regular_selling_price(p.product_id, now()),
+       0,
+       @destination_branch_uuid::uuid,
+       p.measurement_unit,
+       p.organization_id
+FROM products p
+WHERE p.product_id = @source_product_id
+ON CONFLICT (branch_uuid, unique_name) DO UPDATE
+    SET unique_name = EXCLUDED.unique_name
+    RETURNING product_id;
+
+
+-- name: SetStockTransferLineDestination :exec
+UPDATE stock_transfer_lines
+SET destination_product_id = $2
+WHERE line_id = $1;
+
+
+-- name: AddStockTransferLineReceivedQuantity :one
+UPDATE stock_transfer_lines
+SET received_quantity = received_quantity + @quantity::numeric
+WHERE line_id = @line_id
+  AND transfer_id = @transfer_id
+  AND received_quantity + @quantity::numeric <= quantity
+    RETURNING *;
+
+
+-- name: DispatchStockTransfer :one
+UPDATE stock_transfers
+SET status        = 'dispatched',
+    dispatched_at = now()
+WHERE transfer_id = $1
+  AND status = 'draft'
+    RETURNING *;
+
+
+-- name: CompleteStockTransfer :one
+UPDATE stock_transfers
+SET status      = 'received',
+    received_at = now()
+WHERE transfer_id = $1
+  AND status = 'dispatched'
+    RETURNING *;
+
+
+-- name: CancelStockTransfer :one
+UPDATE stock_transfers
+SET status = 'cancelled'
+WHERE transfer_id = $1
+  AND status IN ('draft', 'dispatched')
+    RETURNING *;
+
+
+-- name: SetStockTransferLineUnitCost :exec
+UPDATE stock_transfer_lines
+SET unit_cost = $2
+WHERE line_id = $1;
@@ -0,0 +1,51 @@
+-- name: InsertUnit :one
+INSERT INTO units (organization_id, name, base_unit, factor)
+VALUES (@organization_id, @name, @base_unit, @factor)
+    RETURNING *;
+
+
+-- name: GetUnit :one
+SELECT *
+FROM units
+WHERE organization_id = @organization_id
+  AND name = @name;
+
+
+-- name: ListUnits :many
+SELECT *
+FROM units
+WHERE organization_id = @organization_id
+ORDER BY base_unit, factor, name;
+
+
+-- name: GetProductMeasurementUnit :one
+SELECT branch_uuid, measurement_unit
+FROM products
+WHERE product_id = @product_id
+  AND organization_id = @organization_id;
+
+
+-- name: UpsertProductUnit :one
+INSERT INTO product_units (product_id, unit_name, factor)
+VALUES (@product_id, @unit_name, @factor)
+ON CONFLICT (product_id, unit_name) DO UPDATE
+    SET factor = EXCLUDED.factor
+    RETURNING *;
+
+
+-- name: DeleteProductUnit :execrows
+DELETE
+FROM product_units
+WHERE product_id = @product_id
+  AND unit_name = @unit_name;
+
+
+-- name: ListProductUnits :many
+SELECT *
+FROM product_units
+WHERE product_id = @product_id
+ORDER BY factor, unit_name;
+
+
+-- name: GetProductUnitFactor :one
+SELECT COALESCE(product_unit_factor(@product_id, @unit), 0)::numeric AS factor;
@@ -65,3 +65,10 @@ SELECT *
 FROM organization
 WHERE name = $1;
 
+
+-- name: GetUserOrganizationBranch :one
+SELECT *
+FROM user_organization_branch
+WHERE user_profile_id = $1
+  AND organization_id = $2;
+
@@ -0,0 +1,142 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+// PeriodPurchases is the purchase spend of one branch in one period.
+type PeriodPurchases struct {
+	PeriodStart   time.Time       `json:"period_start"`
+	BranchUuid    uuid.UUID       `json:"branch_uuid"`
+	BranchName    string          `json:"branch_name"`
+	PurchaseCount int64           `json:"purchase_count"`
+	TotalCost     decimal.Decimal `json:"total_cost"`
+}
+
+// PricePoint is the purchase price of a product over one period.
+// AverageUnitPrice is weighted by the units bought.
+type PricePoint struct {
+	PeriodStart      time.Time       `json:"period_start"`
+	Units            decimal.Decimal `json:"units"`
+	AverageUnitPrice decimal.Decimal `json:"average_unit_price"`
+	MinUnitPrice     decimal.Decimal `json:"min_unit_price"`
+	MaxUnitPrice     decimal.Decimal `json:"max_unit_price"`
+}
+
+// SpendBySupplier returns the purchase spend per supplier, largest first.
+// Purchases linked to a partner are grouped by partner; the others are
+// grouped by their free-text supplier name.
+func (s *Service) SpendBySupplier(ctx context.Context, req Request) ([]generated.ListPurchaseSpendBySupplierRow, error) {
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	return q.ListPurchaseSpendBySupplier(ctx, generated.ListPurchaseSpendBySupplierParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+}
+
+// SpendByPeriod returns the purchase spend per branch for each day, week or
+// month of the range.
+func (s *Service) SpendByPeriod(ctx context.Context, req Request, period Period) ([]PeriodPurchases, error) {
+	if err := checkPeriod(period); err != nil {
+		return nil, err
+	}
+	loc, err := location(req)
+	if err != nil {
+		return nil, err
+	}
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListPurchaseSpendByPeriod(ctx, generated.ListPurchaseSpendByPeriodParams{
+		Period:         string(period),
+		TimeZone:       loc.String(),
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list purchase spend by period: %w", err)
+	}
+	spend := make([]PeriodPurchases, 0, len(rows))
+	for _, r := range rows {
+		spend = append(spend, PeriodPurchases{
+			PeriodStart:   r.PeriodStart.In(loc),
+			BranchUuid:    r.BranchUuid,
+			BranchName:    r.BranchName,
+			PurchaseCount: r.PurchaseCount,
+			TotalCost:     r.TotalCost,
+		})
+	}
+	return spend, nil
+}
+
+// PurchasePriceTrend returns the unit purchase price of a product for each
+// day, week or month of the range in which it was bought.
+func (s *Service) PurchasePriceTrend(ctx context.Context, req Request, productID uuid.UUID, period Period) ([]PricePoint, error) {
+	if err := checkPeriod(period); err != nil {
+		return nil, err
+	}
+	loc, err := location(req)
+	if err != nil {
+		return nil, err
+	}
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListProductPurchasePriceTrend(ctx, generated.ListProductPurchasePriceTrendParams{
+		Period:         string(period),
+		TimeZone:       loc.String(),
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		ProductID:      uuid.NullUUID{UUID: productID, Valid: true},
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list purchase price trend: %w", err)
+	}
+	trend := make([]PricePoint, 0, len(rows))
+	for _, r := range rows {
+		trend = append(trend, PricePoint{
+			PeriodStart:      r.PeriodStart.In(loc),
+			Units:            r.Units,
+			AverageUnitPrice: r.AverageUnitPrice,
+			MinUnitPrice:     r.MinUnitPrice,
+			MaxUnitPrice:     r.MaxUnitPrice,
+		})
+	}
+	return trend, nil
+}
+
+// NegativeMarginProducts returns the products whose effective selling price
+// is below their average cost or their latest purchase price. Margin is the
+// selling price less the higher of the two, so the worst products come first. The
+// range of req is ignored.
+func (s *Service) NegativeMarginProducts(ctx context.Context, req Request) ([]generated.ListNegativeMarginProductsRow, error) {
+	q := s.db.Queries()
+	branches, err := scope(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	return q.ListNegativeMarginProducts(ctx, generated.ListNegativeMarginProductsParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+	})
+}
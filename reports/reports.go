@@ -0,0 +1,114 @@
+// Package reports aggregates sales and purchases for an organization.
+//
+// Every report covers the half-open range [From, To) and is limited to the
+// branches the requesting user can access. Totals are summed in the
+// database as NUMERIC and returned as decimals, so no precision is lost.
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// Period is the length of a report bucket.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+var (
+	// ErrInvalidRange is returned when a report range is empty or reversed.
+	ErrInvalidRange = errors.New("reports: from must be before to")
+	// ErrInvalidPeriod is returned for periods other than day, week and month.
+	ErrInvalidPeriod = errors.New("reports: period must be day, week or month")
+)
+
+// readerRoles may run reports.
+var readerRoles = []access.Role{
+	access.RoleAdmin, access.RoleAdminReadOnly, access.RoleBranchManager, access.RoleBranchReadOnly,
+}
+
+// Request selects the data a report covers. BranchUuids limits the report to
+// some branches; when empty it covers every branch the user can access.
+// TimeZone is an IANA zone name used to bucket periods and defaults to UTC.
+type Request struct {
+	OrganizationID uuid.UUID
+	UserProfileID  uuid.UUID
+	BranchUuids    []uuid.UUID
+	From           time.Time
+	To             time.Time
+	TimeZone       string
+}
+
+// Service runs reports.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a reports Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// scopeRange checks the range of req and then scopes it like scope.
+func scopeRange(ctx context.Context, q *generated.Queries, req Request) ([]uuid.UUID, error) {
+	if !req.From.Before(req.To) {
+		return nil, ErrInvalidRange
+	}
+	return scope(ctx, q, req)
+}
+
+// scope checks the user may run a report for req and returns the branches
+// to report on, nil meaning every branch of the organization.
+func scope(ctx context.Context, q *generated.Queries, req Request) ([]uuid.UUID, error) {
+	actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasRole(readerRoles...) {
+		return nil, fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	for _, branchUuid := range req.BranchUuids {
+		if !actor.CanAccessBranch(branchUuid) {
+			return nil, fmt.Errorf("branch %s: %w", branchUuid, access.ErrForbidden)
+		}
+	}
+	if len(req.BranchUuids) > 0 {
+		return req.BranchUuids, nil
+	}
+	if actor.HasRole(access.RoleAdmin, access.RoleAdminReadOnly) {
+		return nil, nil
+	}
+	return append([]uuid.UUID{}, actor.BranchUuids...), nil
+}
+
+// checkPeriod returns ErrInvalidPeriod unless period is a day, week or month.
+func checkPeriod(period Period) error {
+	switch period {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return nil
+	}
+	return fmt.Errorf("%q: %w", period, ErrInvalidPeriod)
+}
+
+// location resolves the time zone of req.
+func location(req Request) (*time.Location, error) {
+	if req.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(req.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("reports: time zone %q: %w", req.TimeZone, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,149 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+const (
+	// averagePlaces is the precision of average sale values.
+	averagePlaces = 2
+	// defaultTopProducts is the number of top products returned when no
+	// limit is given.
+	defaultTopProducts = 10
+)
+
+// PeriodSales is the sales of one branch in one period. PeriodStart is the
+// start of the period in the report's time zone; weeks start on Monday.
+type PeriodSales struct {
+	PeriodStart time.Time       `json:"period_start"`
+	BranchUuid  uuid.UUID       `json:"branch_uuid"`
+	BranchName  string          `json:"branch_name"`
+	SalesCount  int64           `json:"sales_count"`
+	Revenue     decimal.Decimal `json:"revenue"`
+	Profit      decimal.Decimal `json:"profit"`
+}
+
+// CashierSales is the sales recorded by one user.
+type CashierSales struct {
+	UserProfileID uuid.UUID       `json:"user_profile_id"`
+	FullName      string          `json:"full_name"`
+	SalesCount    int64           `json:"sales_count"`
+	Revenue       decimal.Decimal `json:"revenue"`
+	Profit        decimal.Decimal `json:"profit"`
+	AverageSale   decimal.Decimal `json:"average_sale"`
+}
+
+// SalesByPeriod returns revenue and profit per branch for each day, week or
+// month of the range.
+func (s *Service) SalesByPeriod(ctx context.Context, req Request, period Period) ([]PeriodSales, error) {
+	if err := checkPeriod(period); err != nil {
+		return nil, err
+	}
+	loc, err := location(req)
+	if err != nil {
+		return nil, err
+	}
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListSalesByPeriod(ctx, generated.ListSalesByPeriodParams{
+		Period:         string(period),
+		TimeZone:       loc.String(),
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list sales by period: %w", err)
+	}
+	sales := make([]PeriodSales, 0, len(rows))
+	for _, r := range rows {
+		sales = append(sales, PeriodSales{
+			PeriodStart: r.PeriodStart.In(loc),
+			BranchUuid:  r.BranchUuid,
+			BranchName:  r.BranchName,
+			SalesCount:  r.SalesCount,
+			Revenue:     r.Revenue,
+			Profit:      r.Profit,
+		})
+	}
+	return sales, nil
+}
+
+// TopSellingProducts returns the products with the largest quantity sold,
+// at most limit of them.
+func (s *Service) TopSellingProducts(ctx context.Context, req Request, limit int32) ([]generated.ListTopSellingProductsRow, error) {
+	if limit <= 0 {
+		limit = defaultTopProducts
+	}
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	return q.ListTopSellingProducts(ctx, generated.ListTopSellingProductsParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+		RowLimit:       limit,
+	})
+}
+
+// SalesByPaymentMethod returns revenue and profit per payment method. A sale
+// settled by several tenders counts towards each of their methods, with its
+// profit shared in proportion to the tendered amounts. Sales without a
+// payment method are grouped under an empty method.
+func (s *Service) SalesByPaymentMethod(ctx context.Context, req Request) ([]generated.ListSalesByPaymentMethodRow, error) {
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	return q.ListSalesByPaymentMethod(ctx, generated.ListSalesByPaymentMethodParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+}
+
+// CashierPerformance returns the sales recorded by each user, largest
+// revenue first.
+func (s *Service) CashierPerformance(ctx context.Context, req Request) ([]CashierSales, error) {
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.ListCashierPerformance(ctx, generated.ListCashierPerformanceParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list cashier performance: %w", err)
+	}
+	cashiers := make([]CashierSales, 0, len(rows))
+	for _, r := range rows {
+		cashiers = append(cashiers, CashierSales{
+			UserProfileID: r.UserProfileID,
+			FullName:      r.FullName,
+			SalesCount:    r.SalesCount,
+			Revenue:       r.Revenue,
+			Profit:        r.Profit,
+			AverageSale:   r.Revenue.DivRound(decimal.NewFromInt(r.SalesCount), averagePlaces),
+		})
+	}
+	return cashiers, nil
+}
@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+// TaxSummary is the tax charged on sales over a filing period, per rate and
+// in total.
+type TaxSummary struct {
+	From          time.Time                     `json:"from"`
+	To            time.Time                     `json:"to"`
+	Rates         []generated.ListTaxSummaryRow `json:"rates"`
+	TaxableAmount decimal.Decimal               `json:"taxable_amount"`
+	TaxAmount     decimal.Decimal               `json:"tax_amount"`
+	Total         decimal.Decimal               `json:"total"`
+}
+
+// TaxSummary returns the taxable amount, tax and total of the sales of the
+// range for each tax rate charged, lowest rate first. Lines sold before tax
+// was configured are reported at a rate of zero.
+func (s *Service) TaxSummary(ctx context.Context, req Request) (TaxSummary, error) {
+	q := s.db.Queries()
+	branches, err := scopeRange(ctx, q, req)
+	if err != nil {
+		return TaxSummary{}, err
+	}
+	rows, err := q.ListTaxSummary(ctx, generated.ListTaxSummaryParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		FromTime:       req.From,
+		ToTime:         req.To,
+	})
+	if err != nil {
+		return TaxSummary{}, fmt.Errorf("list tax summary: %w", err)
+	}
+	summary := TaxSummary{From: req.From, To: req.To, Rates: rows}
+	for _, r := range rows {
+		summary.TaxableAmount = summary.TaxableAmount.Add(r.TaxableAmount)
+		summary.TaxAmount = summary.TaxAmount.Add(r.TaxAmount)
+		summary.Total = summary.Total.Add(r.Total)
+	}
+	return summary, nil
+}
@@ -0,0 +1,339 @@
+// Package sales records sales at the till. A checkout writes the sales group
+// and its lines, takes the sold quantities out of stock through the ledger,
+// charges each line its cost of goods sold from the costing engine and
+// works out its tax in the organization's price mode.
+package sales
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/costing"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/inventory"
+	"github.com/sushan531/auth-sqlc/partners"
+	"github.com/sushan531/auth-sqlc/payments"
+	"github.com/sushan531/auth-sqlc/shifts"
+	"github.com/sushan531/auth-sqlc/store"
+	"github.com/sushan531/auth-sqlc/tax"
+	"github.com/sushan531/auth-sqlc/units"
+)
+
+var (
+	// ErrEmptySale is returned when a checkout has no lines.
+	ErrEmptySale = errors.New("sales: sale has no lines")
+	// ErrInvalidQuantity is returned when a line quantity is not positive.
+	ErrInvalidQuantity = errors.New("sales: quantity must be positive")
+	// ErrProductNotFound is returned when a product is not sold at the branch.
+	ErrProductNotFound = errors.New("sales: product not found in branch")
+	// ErrSaleNotFound is returned when a sales group does not exist in the organization.
+	ErrSaleNotFound = errors.New("sales: sale not found")
+	// ErrCreditWithoutCustomer is returned when a sale on credit has no customer.
+	ErrCreditWithoutCustomer = errors.New("sales: credit sales need a customer")
+)
+
+// sellerRoles may record sales.
+var sellerRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager, access.RoleSales}
+
+// LineItem is a product sold at checkout. Quantity is in Unit, or in the
+// product's measurement unit when Unit is empty; Unit must convert to it.
+// SalesPrice is the price of one Unit and overrides the product's effective
+// selling price at sale time, converted to Unit, when it is valid; either is
+// read in the organization's price mode.
+type LineItem struct {
+	ProductID  uuid.UUID
+	Quantity   decimal.Decimal
+	Unit       string
+	SalesPrice decimal.NullDecimal
+}
+
+// CheckoutRequest describes a sale. The sale is linked to the user's open
+// shift at the branch, if any. Tenders settle the sale and must sum to
+// its total; without tenders, PaymentMethod settles the whole total. A sale
+// with neither is recorded unpaid.
+// CustomerID attaches the sale to a customer partner, whose name is used
+// when CustomerName is empty. A sale with a credit tender must have a
+// customer; the credit tender is added to what the customer owes.
+type CheckoutRequest struct {
+	OrganizationID uuid.UUID
+	BranchUuid     uuid.UUID
+	UserProfileID  uuid.UUID
+	PaymentMethod  string
+	Tenders        []payments.Tender
+	CustomerID     uuid.NullUUID
+	CustomerName   string
+	Comments       string
+	Lines          []LineItem
+}
+
+// Sale is a sales group with its lines and tenders. ChangeDue is the cash to
+// hand back to the customer.
+type Sale struct {
+	generated.SalesGroup
+	Lines     []generated.Sale        `json:"lines"`
+	Tenders   []generated.SalesTender `json:"tenders"`
+	ChangeDue decimal.Decimal         `json:"change_due"`
+}
+
+// Service records sales.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a sales Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Checkout records a sale in one transaction.
+func (s *Service) Checkout(ctx context.Context, req CheckoutRequest) (Sale, error) {
+	if len(req.Lines) == 0 {
+		return Sale{}, ErrEmptySale
+	}
+	var sale Sale
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if err := actor.RequireBranchRole(req.BranchUuid, sellerRoles...); err != nil {
+			return err
+		}
+		method, err := costing.MethodFor(ctx, q, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+		mode, err := tax.PriceModeFor(ctx, q, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+		customerName := req.CustomerName
+		if req.CustomerID.Valid {
+			customer, err := partners.RequireCustomer(ctx, q, req.CustomerID.UUID, req.OrganizationID)
+			if err != nil {
+				return err
+			}
+			if customerName == "" {
+				customerName = customer.PartnerName
+			}
+		}
+
+		products, err := lockProducts(ctx, q, req)
+		if err != nil {
+			return err
+		}
+		factors, err := convertLines(ctx, q, req.Lines, products)
+		if err != nil {
+			return err
+		}
+
+		lines := make([]generated.InsertSaleParams, 0, len(req.Lines))
+		var totals tax.Amounts
+		totalProfit := decimal.Zero
+		for i, item := range req.Lines {
+			product := products[item.ProductID]
+			factor := factors[i]
+			quantity := item.Quantity.Mul(factor)
+			salesID, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("generate sales id: %w", err)
+			}
+			cost, err := costing.IssueStock(ctx, q, costing.Issue{
+				ProductID:   item.ProductID,
+				ReferenceID: salesID,
+				Quantity:    quantity,
+				Method:      method,
+			})
+			if err != nil {
+				return err
+			}
+
+			price := product.SellingPrice.Mul(factor)
+			if item.SalesPrice.Valid {
+				price = item.SalesPrice.Decimal
+			}
+			amounts := tax.Compute(price, item.Quantity, product.TaxRate, mode)
+			profit := amounts.Taxable.Sub(cost.Total)
+			totals = totals.Add(amounts)
+			totalProfit = totalProfit.Add(profit)
+			lines = append(lines, generated.InsertSaleParams{
+				SalesID:          salesID,
+				ProductID:        item.ProductID,
+				Quantity:         quantity,
+				CurrentCostPrice: cost.UnitCost,
+				SalesPrice:       price.Div(factor),
+				Total:            amounts.Total,
+				Profit:           profit,
+				TaxRate:          product.TaxRate,
+				TaxableAmount:    amounts.Taxable,
+				TaxAmount:        amounts.Tax,
+				SoldUnit:         soldUnit(item, product),
+				SoldQuantity:     item.Quantity,
+			})
+		}
+
+		tenders := req.Tenders
+		if len(tenders) == 0 {
+			tenders = payments.Single(req.PaymentMethod, totals.Total)
+		}
+		var settlement payments.Settlement
+		if len(tenders) > 0 {
+			settlement, err = payments.Settle(ctx, q, req.OrganizationID, totals.Total, tenders)
+			if err != nil {
+				return err
+			}
+		}
+		if settlement.Credit.IsPositive() && !req.CustomerID.Valid {
+			return ErrCreditWithoutCustomer
+		}
+		shiftID, err := shifts.OpenShiftFor(ctx, q, req.UserProfileID, req.BranchUuid)
+		if err != nil {
+			return err
+		}
+
+		sale.SalesGroup, err = q.InsertSalesGroup(ctx, generated.InsertSalesGroupParams{
+			TotalAmount:    totals.Total,
+			TotalProfit:    totalProfit,
+			PaymentMethod:  settlement.PaymentMethod(),
+			BranchUuid:     req.BranchUuid,
+			UserProfileID:  req.UserProfileID,
+			OrganizationID: req.OrganizationID,
+			CustomerName:   sql.NullString{String: customerName, Valid: customerName != ""},
+			Comments:       sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+			PriceMode:      mode,
+			TaxableAmount:  totals.Taxable,
+			TaxAmount:      totals.Tax,
+			PartnerID:      req.CustomerID,
+			ShiftID:        shiftID,
+		})
+		if err != nil {
+			return fmt.Errorf("insert sales group: %w", err)
+		}
+		sale.Tenders, err = payments.RecordSaleTenders(ctx, q, sale.SalesGroupID, settlement)
+		if err != nil {
+			return err
+		}
+		sale.ChangeDue = settlement.ChangeDue
+
+		for _, line := range lines {
+			line.SalesGroupID = uuid.NullUUID{UUID: sale.SalesGroupID, Valid: true}
+			inserted, err := q.InsertSale(ctx, line)
+			if err != nil {
+				return fmt.Errorf("insert sale of product %s: %w", line.ProductID, err)
+			}
+			_, err = inventory.RecordMovement(ctx, q, inventory.Movement{
+				ProductID:      line.ProductID,
+				BranchUuid:     req.BranchUuid,
+				OrganizationID: req.OrganizationID,
+				Type:           generated.MovementTypeSale,
+				Quantity:       line.Quantity.Neg(),
+				ReferenceID:    uuid.NullUUID{UUID: line.SalesID, Valid: true},
+				UserProfileID:  uuid.NullUUID{UUID: req.UserProfileID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			sale.Lines = append(sale.Lines, inserted)
+		}
+		return nil
+	})
+	return sale, err
+}
+
+// GetSale returns a sales group and its lines.
+func (s *Service) GetSale(ctx context.Context, salesGroupID, organizationID uuid.UUID) (generated.SalesGroup, []generated.ListSalesGroupLinesRow, error) {
+	q := s.db.Queries()
+	group, err := q.GetSalesGroup(ctx, generated.GetSalesGroupParams{
+		SalesGroupID:   salesGroupID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return group, nil, ErrSaleNotFound
+	}
+	if err != nil {
+		return group, nil, fmt.Errorf("get sales group: %w", err)
+	}
+	lines, err := q.ListSalesGroupLines(ctx, uuid.NullUUID{UUID: salesGroupID, Valid: true})
+	if err != nil {
+		return group, nil, fmt.Errorf("list sales lines: %w", err)
+	}
+	return group, lines, nil
+}
+
+// lockProducts locks the products of a checkout and checks they are sold at
+// the branch.
+func lockProducts(ctx context.Context, q *generated.Queries, req CheckoutRequest) (map[uuid.UUID]generated.LockBranchProductsRow, error) {
+	ids := make([]uuid.UUID, 0, len(req.Lines))
+	seen := make(map[uuid.UUID]bool, len(req.Lines))
+	for _, item := range req.Lines {
+		if !item.Quantity.IsPositive() {
+			return nil, fmt.Errorf("product %s: %w", item.ProductID, ErrInvalidQuantity)
+		}
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+
+	rows, err := q.LockBranchProducts(ctx, generated.LockBranchProductsParams{
+		OrganizationID: req.OrganizationID,
+		BranchUuid:     req.BranchUuid,
+		ProductIds:     ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lock products: %w", err)
+	}
+	products := make(map[uuid.UUID]generated.LockBranchProductsRow, len(rows))
+	for _, p := range rows {
+		products[p.ProductID] = p
+	}
+	for _, id := range ids {
+		if _, ok := products[id]; !ok {
+			return nil, fmt.Errorf("product %s: %w", id, ErrProductNotFound)
+		}
+	}
+	return products, nil
+}
+
+// convertLines returns the factor that converts each line to its product's
+// measurement unit and checks the branch holds enough stock for every line.
+func convertLines(ctx context.Context, q *generated.Queries, lines []LineItem, products map[uuid.UUID]generated.LockBranchProductsRow) ([]decimal.Decimal, error) {
+	factors := make([]decimal.Decimal, len(lines))
+	wanted := make(map[uuid.UUID]decimal.Decimal, len(products))
+	ids := make([]uuid.UUID, 0, len(products))
+	for i, item := range lines {
+		factors[i] = decimal.NewFromInt(1)
+		if unit := soldUnit(item, products[item.ProductID]); unit != products[item.ProductID].MeasurementUnit {
+			factor, err := units.Factor(ctx, q, item.ProductID, unit)
+			if err != nil {
+				return nil, err
+			}
+			factors[i] = factor
+		}
+		if _, ok := wanted[item.ProductID]; !ok {
+			ids = append(ids, item.ProductID)
+		}
+		wanted[item.ProductID] = wanted[item.ProductID].Add(item.Quantity.Mul(factors[i]))
+	}
+	for _, id := range ids {
+		p := products[id]
+		if p.RemainingQuantity.LessThan(wanted[id]) {
+			return nil, fmt.Errorf("%s has %s %s, sale needs %s: %w",
+				p.UniqueName, p.RemainingQuantity, p.MeasurementUnit, wanted[id], inventory.ErrInsufficientStock)
+		}
+	}
+	return factors, nil
+}
+
+// soldUnit returns the unit a line is sold in.
+func soldUnit(item LineItem, product generated.LockBranchProductsRow) string {
+	if item.Unit == "" {
+		return product.MeasurementUnit
+	}
+	return item.Unit
+}
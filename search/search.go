@@ -0,0 +1,147 @@
+// Package search finds products by name, unique name and description.
+//
+// Each word of a search matches whole words and word prefixes through the
+// full-text index, so "lap sta" finds "Laptop Stand". The whole search also
+// matches by trigram similarity, which finds partial names and survives
+// typos such as "labtop". Results are ranked by relevance.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+const (
+	// DefaultPageSize is used when a request does not set a page size.
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page Products returns.
+	MaxPageSize = 100
+)
+
+// ErrEmptyQuery is returned when a search has no letters or digits.
+var ErrEmptyQuery = errors.New("search: query has no words")
+
+// Request is a product search. Without BranchUuids, the search covers every
+// branch the user can access.
+type Request struct {
+	OrganizationID uuid.UUID
+	UserProfileID  uuid.UUID
+	BranchUuids    []uuid.UUID
+	Query          string
+	// Offset is the NextOffset of the previous page.
+	Offset   int
+	PageSize int
+}
+
+// Page is one page of search results, best match first. NameHighlight and
+// DescriptionSnippet are HTML: product text is escaped and matched words
+// are marked with <mark> and </mark>. NextOffset is the Offset of the next
+// page, or 0 when there are no more results.
+type Page struct {
+	Products   []generated.SearchProductsRow `json:"products"`
+	NextOffset int                           `json:"next_offset"`
+}
+
+// Service searches products.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a search Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Products returns a page of the products matching req.Query.
+func (s *Service) Products(ctx context.Context, req Request) (Page, error) {
+	term := strings.Join(strings.Fields(req.Query), " ")
+	prefixQuery := prefixQuery(term)
+	if prefixQuery == "" {
+		return Page{}, ErrEmptyQuery
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+	offset := max(req.Offset, 0)
+
+	q := s.db.Queries()
+	branches, err := scope(ctx, q, req)
+	if err != nil {
+		return Page{}, err
+	}
+	// Read one row past the page to know whether another page follows.
+	products, err := q.SearchProducts(ctx, generated.SearchProductsParams{
+		PrefixQuery:    prefixQuery,
+		Term:           term,
+		OrganizationID: req.OrganizationID,
+		BranchUuids:    branches,
+		PageSize:       int32(pageSize + 1),
+		PageOffset:     int32(offset),
+	})
+	if err != nil {
+		return Page{}, fmt.Errorf("search products: %w", err)
+	}
+	for i := range products {
+		products[i].NameHighlight = highlight(products[i].NameHighlight)
+		products[i].DescriptionSnippet = highlight(products[i].DescriptionSnippet)
+	}
+	page := Page{Products: products}
+	if len(products) > pageSize {
+		page.Products = products[:pageSize]
+		page.NextOffset = offset + pageSize
+	}
+	return page, nil
+}
+
+// markers turns the match markers of SearchProducts into HTML.
+var markers = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+// highlight escapes the product text of a headline, then marks its matches.
+func highlight(headline string) string {
+	return markers.Replace(html.EscapeString(headline))
+}
+
+// prefixQuery turns a search into a tsquery matching every word as a
+// prefix, such as "lap:* & sta:*". Only letters and digits are kept, so the
+// result is always valid tsquery syntax.
+func prefixQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = strings.ToLower(w) + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// scope checks the user may search the branches of req and returns the
+// branches to search, nil meaning every branch of the organization.
+func scope(ctx context.Context, q *generated.Queries, req Request) ([]uuid.UUID, error) {
+	actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, branchUuid := range req.BranchUuids {
+		if !actor.CanAccessBranch(branchUuid) {
+			return nil, fmt.Errorf("branch %s: %w", branchUuid, access.ErrForbidden)
+		}
+	}
+	if len(req.BranchUuids) > 0 {
+		return req.BranchUuids, nil
+	}
+	if actor.HasRole(access.RoleAdmin, access.RoleAdminReadOnly) {
+		return nil, nil
+	}
+	return append([]uuid.UUID{}, actor.BranchUuids...), nil
+}
@@ -0,0 +1,291 @@
+// Package shifts ties sales to cash drawer shifts and reconciles the drawer
+// at the end of each shift.
+//
+// A user opens a shift at a branch with an opening float. Sales the user
+// rings up at that branch while the shift is open are linked to it. Closing
+// the shift records the cash counted in the drawer and produces a Z-report
+// comparing it with the cash the drawer should hold: the float plus the cash
+// tenders of the shift's sales, net of change given.
+package shifts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+var (
+	// ErrShiftNotFound is returned when a shift does not exist in the organization.
+	ErrShiftNotFound = errors.New("shifts: shift not found")
+	// ErrShiftOpen is returned when opening a shift while the user already has one open at the branch.
+	ErrShiftOpen = errors.New("shifts: user already has an open shift at the branch")
+	// ErrNoOpenShift is returned when the user has no open shift at the branch.
+	ErrNoOpenShift = errors.New("shifts: no open shift")
+	// ErrShiftClosed is returned when closing a shift that is already closed.
+	ErrShiftClosed = errors.New("shifts: shift is already closed")
+	// ErrInvalidAmount is returned when a float or counted amount is negative.
+	ErrInvalidAmount = errors.New("shifts: cash amounts must not be negative")
+)
+
+// tillRoles may open shifts and close their own.
+var tillRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager, access.RoleSales}
+
+// supervisorRoles may close and report on the shifts of other users in
+// their branches.
+var supervisorRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// OpenRequest describes a shift to open.
+type OpenRequest struct {
+	OrganizationID uuid.UUID
+	BranchUuid     uuid.UUID
+	UserProfileID  uuid.UUID
+	OpeningFloat   decimal.Decimal
+	Comments       string
+}
+
+// CloseRequest describes the close of a shift by UserProfileID, who may be
+// the shift's own user or a supervisor.
+type CloseRequest struct {
+	OrganizationID uuid.UUID
+	ShiftID        uuid.UUID
+	UserProfileID  uuid.UUID
+	CountedCash    decimal.Decimal
+	// Comments are appended to the shift's opening comments on a new line.
+	Comments string
+}
+
+// ZReport summarises the sales and cash of a shift. For an open shift it is
+// a running report: CountedCash and Variance are zero.
+type ZReport struct {
+	Shift         generated.Shift                 `json:"shift"`
+	SalesCount    int64                           `json:"sales_count"`
+	TotalAmount   decimal.Decimal                 `json:"total_amount"`
+	TaxableAmount decimal.Decimal                 `json:"taxable_amount"`
+	TaxAmount     decimal.Decimal                 `json:"tax_amount"`
+	Tenders       []generated.ListShiftTendersRow `json:"tenders"`
+	// CashSales is the cash kept from sales, after change was given.
+	CashSales    decimal.Decimal `json:"cash_sales"`
+	ExpectedCash decimal.Decimal `json:"expected_cash"`
+	CountedCash  decimal.Decimal `json:"counted_cash"`
+	// Variance is CountedCash less ExpectedCash; negative when the drawer
+	// is short.
+	Variance decimal.Decimal `json:"variance"`
+}
+
+// Service opens and closes shifts.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a shifts Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Open starts a shift for a user at a branch.
+func (s *Service) Open(ctx context.Context, req OpenRequest) (generated.Shift, error) {
+	if req.OpeningFloat.IsNegative() {
+		return generated.Shift{}, ErrInvalidAmount
+	}
+	var shift generated.Shift
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, req.UserProfileID, req.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if err := actor.RequireBranchRole(req.BranchUuid, tillRoles...); err != nil {
+			return err
+		}
+		open, err := OpenShiftFor(ctx, q, req.UserProfileID, req.BranchUuid)
+		if err != nil {
+			return err
+		}
+		if open.Valid {
+			return fmt.Errorf("shift %s: %w", open.UUID, ErrShiftOpen)
+		}
+		shift, err = q.OpenShift(ctx, generated.OpenShiftParams{
+			BranchUuid:     req.BranchUuid,
+			OrganizationID: req.OrganizationID,
+			UserProfileID:  req.UserProfileID,
+			OpeningFloat:   req.OpeningFloat,
+			Comments:       sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+		})
+		if store.IsUniqueViolation(err) {
+			return ErrShiftOpen
+		}
+		if err != nil {
+			return fmt.Errorf("open shift: %w", err)
+		}
+		return nil
+	})
+	return shift, err
+}
+
+// Current returns the open shift of a user at a branch.
+func (s *Service) Current(ctx context.Context, userProfileID, branchUuid uuid.UUID) (generated.Shift, error) {
+	shift, err := s.db.Queries().GetOpenShift(ctx, generated.GetOpenShiftParams{
+		UserProfileID: userProfileID,
+		BranchUuid:    branchUuid,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return shift, ErrNoOpenShift
+	}
+	if err != nil {
+		return shift, fmt.Errorf("get open shift: %w", err)
+	}
+	return shift, nil
+}
+
+// Shifts returns the shifts opened at a branch in [from, to).
+func (s *Service) Shifts(ctx context.Context, organizationID, branchUuid uuid.UUID, from, to time.Time) ([]generated.Shift, error) {
+	return s.db.Queries().ListBranchShifts(ctx, generated.ListBranchShiftsParams{
+		OrganizationID: organizationID,
+		BranchUuid:     branchUuid,
+		FromTime:       from,
+		ToTime:         to,
+	})
+}
+
+// Close records the cash counted in the drawer, closes the shift and
+// returns its Z-report.
+func (s *Service) Close(ctx context.Context, req CloseRequest) (ZReport, error) {
+	if req.CountedCash.IsNegative() {
+		return ZReport{}, ErrInvalidAmount
+	}
+	var report ZReport
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		shift, err := q.LockShift(ctx, generated.LockShiftParams{
+			ShiftID:        req.ShiftID,
+			OrganizationID: req.OrganizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrShiftNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("lock shift: %w", err)
+		}
+		if err := authorize(ctx, q, req.UserProfileID, shift); err != nil {
+			return err
+		}
+		if shift.Status == generated.ShiftStatusClosed {
+			return ErrShiftClosed
+		}
+
+		report, err = summarise(ctx, q, shift)
+		if err != nil {
+			return err
+		}
+		report.Shift, err = q.CloseShift(ctx, generated.CloseShiftParams{
+			ExpectedCash: report.ExpectedCash,
+			CountedCash:  req.CountedCash,
+			ClosedBy:     uuid.NullUUID{UUID: req.UserProfileID, Valid: true},
+			Comments:     sql.NullString{String: req.Comments, Valid: req.Comments != ""},
+			ShiftID:      shift.ShiftID,
+		})
+		if err != nil {
+			return fmt.Errorf("close shift: %w", err)
+		}
+		report.CountedCash = req.CountedCash
+		report.Variance = req.CountedCash.Sub(report.ExpectedCash)
+		return nil
+	})
+	return report, err
+}
+
+// Report returns the Z-report of a closed shift, or a running report of an
+// open one.
+func (s *Service) Report(ctx context.Context, organizationID, shiftID, userProfileID uuid.UUID) (ZReport, error) {
+	q := s.db.Queries()
+	shift, err := q.GetShift(ctx, generated.GetShiftParams{
+		ShiftID:        shiftID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ZReport{}, ErrShiftNotFound
+	}
+	if err != nil {
+		return ZReport{}, fmt.Errorf("get shift: %w", err)
+	}
+	if err := authorize(ctx, q, userProfileID, shift); err != nil {
+		return ZReport{}, err
+	}
+	report, err := summarise(ctx, q, shift)
+	if err != nil {
+		return ZReport{}, err
+	}
+	if shift.Status == generated.ShiftStatusClosed {
+		// The expected cash was fixed at close.
+		report.ExpectedCash = shift.ExpectedCash
+		report.CountedCash = shift.CountedCash
+		report.Variance = shift.CountedCash.Sub(shift.ExpectedCash)
+	}
+	return report, nil
+}
+
+// OpenShiftFor returns the open shift of a user at a branch, if any, with
+// q, for callers that already hold a transaction. The shift is share-locked
+// until the transaction ends, so it cannot be closed under a sale being
+// recorded in it.
+func OpenShiftFor(ctx context.Context, q *generated.Queries, userProfileID, branchUuid uuid.UUID) (uuid.NullUUID, error) {
+	shiftID, err := q.ShareOpenShift(ctx, generated.ShareOpenShiftParams{
+		UserProfileID: userProfileID,
+		BranchUuid:    branchUuid,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, nil
+	}
+	if err != nil {
+		return uuid.NullUUID{}, fmt.Errorf("get open shift: %w", err)
+	}
+	return uuid.NullUUID{UUID: shiftID, Valid: true}, nil
+}
+
+// summarise totals the sales and tenders of a shift and works out the cash
+// the drawer should hold.
+func summarise(ctx context.Context, q *generated.Queries, shift generated.Shift) (ZReport, error) {
+	shiftID := uuid.NullUUID{UUID: shift.ShiftID, Valid: true}
+	totals, err := q.GetShiftSalesTotals(ctx, shiftID)
+	if err != nil {
+		return ZReport{}, fmt.Errorf("get shift sales totals: %w", err)
+	}
+	tenders, err := q.ListShiftTenders(ctx, shiftID)
+	if err != nil {
+		return ZReport{}, fmt.Errorf("list shift tenders: %w", err)
+	}
+	report := ZReport{
+		Shift:         shift,
+		SalesCount:    totals.SalesCount,
+		TotalAmount:   totals.TotalAmount,
+		TaxableAmount: totals.TaxableAmount,
+		TaxAmount:     totals.TaxAmount,
+		Tenders:       tenders,
+	}
+	for _, t := range tenders {
+		if t.Kind == generated.PaymentMethodKindCash {
+			report.CashSales = report.CashSales.Add(t.Amount)
+		}
+	}
+	report.ExpectedCash = shift.OpeningFloat.Add(report.CashSales)
+	return report, nil
+}
+
+// authorize checks the user may close or report on a shift: their own with
+// a till role, anyone's in their branch as a supervisor.
+func authorize(ctx context.Context, q *generated.Queries, userProfileID uuid.UUID, shift generated.Shift) error {
+	actor, err := access.LoadActor(ctx, q, userProfileID, shift.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if shift.UserProfileID == userProfileID {
+		return actor.RequireBranchRole(shift.BranchUuid, tillRoles...)
+	}
+	return actor.RequireBranchRole(shift.BranchUuid, supervisorRoles...)
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+// Identity is the user a request acts for. Every change made inside WithTx
+// with a context carrying an Identity is recorded as an activity row, with
+// the row's values before and after the change, by the record_activity
+// trigger in the same transaction.
+type Identity struct {
+	Email          string
+	OrganizationID uuid.UUID
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context that attributes changes to id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the identity carried by ctx.
+func IdentityFrom(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok && id.Email != ""
+}
+
+// RecordLogin records a login attempt by email in an organization. The
+// library never verifies credentials itself, so callers that authenticate
+// users against GetUserAuth must call it after every attempt.
+func (d *DB) RecordLogin(ctx context.Context, email string, organizationID uuid.UUID, succeeded bool) error {
+	err := d.Queries().InsertActivity(ctx, generated.InsertActivityParams{
+		Identity:       email,
+		Operation:      generated.OperationTypeLogin,
+		Resource:       []string{"auth", email},
+		OldValue:       []string{},
+		NewValue:       []string{},
+		Status:         succeeded,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("record login: %w", err)
+	}
+	return nil
+}
+
+var (
+	queryName = regexp.MustCompile(`^-- name: (\w+)`)
+	mutation  = regexp.MustCompile(`(?i)\b(INSERT\s+INTO|UPDATE\s+\w+(\s+(AS\s+)?\w+)?\s+SET|DELETE\s+FROM)\b`)
+)
+
+const tagStatement = `SELECT set_config('audit.identity', $1, true),
+       set_config('audit.organization_id', $2, true),
+       set_config('audit.query', $3, true)`
+
+// auditDBTX tags each mutating statement run in a transaction with the
+// identity from its context, for the record_activity trigger to read. The
+// settings are transaction-local, so the decorator is only installed on
+// transactions.
+type auditDBTX struct {
+	tx *sql.Tx
+}
+
+func (a auditDBTX) tag(ctx context.Context, query string) error {
+	id, ok := IdentityFrom(ctx)
+	if !ok || !mutation.MatchString(query) {
+		return nil
+	}
+	name := ""
+	if m := queryName.FindStringSubmatch(query); m != nil {
+		name = m[1]
+	}
+	if _, err := a.tx.ExecContext(ctx, tagStatement, id.Email, id.OrganizationID.String(), name); err != nil {
+		return fmt.Errorf("tag statement for activity: %w", err)
+	}
+	return nil
+}
+
+func (a auditDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := a.tag(ctx, query); err != nil {
+		return nil, err
+	}
+	return a.tx.ExecContext(ctx, query, args...)
+}
+
+func (a auditDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return a.tx.PrepareContext(ctx, query)
+}
+
+func (a auditDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if err := a.tag(ctx, query); err != nil {
+		return nil, err
+	}
+	return a.tx.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext cannot return the tagging error itself; a failed tag
+// aborts the transaction, so the query then fails when it is scanned.
+func (a auditDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	_ = a.tag(ctx, query)
+	return a.tx.QueryRowContext(ctx, query, args...)
+}
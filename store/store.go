@@ -0,0 +1,61 @@
+// Package store wires the sqlc generated queries to a database handle and
+// runs multi-statement operations inside a single transaction.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/sushan531/auth-sqlc/generated"
+)
+
+// DB wraps a database connection pool for the service packages.
+type DB struct {
+	conn *sql.DB
+}
+
+// New returns a DB backed by conn.
+func New(conn *sql.DB) *DB {
+	return &DB{conn: conn}
+}
+
+// Conn returns the underlying connection pool.
+func (d *DB) Conn() *sql.DB {
+	return d.conn
+}
+
+// Queries returns queries that run outside of any transaction. Changes made
+// through them are not recorded in activity; use WithTx for writes.
+func (d *DB) Queries() *generated.Queries {
+	return generated.New(d.conn)
+}
+
+// WithTx runs fn inside a transaction, committing when fn returns nil and
+// rolling back otherwise. Changes made by fn are attributed to the Identity
+// carried by ctx, if any.
+func (d *DB) WithTx(ctx context.Context, fn func(q *generated.Queries) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(generated.New(auditDBTX{tx: tx})); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint,
+// typically a concurrent insert of the same row. The transaction it happened
+// in is aborted.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,251 @@
+// Package tax configures the tax rates of an organization and works out the
+// tax charged on each sale line.
+//
+// Rates are percentages. A product is taxed at its own rate, or at the
+// organization's default rate when it has none, or not at all. The
+// organization's price mode says whether selling prices already include
+// tax or have it added at checkout.
+package tax
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+// Places is the number of decimal places tax amounts are rounded to.
+const Places = 2
+
+var (
+	// ErrInvalidRate is returned when a rate is negative or has no name.
+	ErrInvalidRate = errors.New("tax: rate needs a name and must not be negative")
+	// ErrRateNotFound is returned when a tax rate does not exist in the organization.
+	ErrRateNotFound = errors.New("tax: tax rate not found")
+	// ErrProductNotFound is returned when a product does not exist in the organization.
+	ErrProductNotFound = errors.New("tax: product not found")
+	// ErrUnknownPriceMode is returned for price modes other than tax_exclusive and tax_inclusive.
+	ErrUnknownPriceMode = errors.New("tax: unknown price mode")
+	// ErrUnknownOrganization is returned when an organization does not exist.
+	ErrUnknownOrganization = errors.New("tax: organization not found")
+)
+
+// adminRoles may change the tax configuration of an organization.
+var adminRoles = []access.Role{access.RoleAdmin}
+
+// productRoles may choose the tax rate of a product in their branches.
+var productRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+var hundred = decimal.NewFromInt(100)
+
+// Amounts is the tax split of a sale line or sale.
+type Amounts struct {
+	// Taxable is the amount before tax.
+	Taxable decimal.Decimal `json:"taxable_amount"`
+	Tax     decimal.Decimal `json:"tax_amount"`
+	// Total is the amount charged, tax included.
+	Total decimal.Decimal `json:"total"`
+}
+
+// Add returns the sum of a and b.
+func (a Amounts) Add(b Amounts) Amounts {
+	return Amounts{Taxable: a.Taxable.Add(b.Taxable), Tax: a.Tax.Add(b.Tax), Total: a.Total.Add(b.Total)}
+}
+
+// Compute works out the tax on quantity units sold at price, taxed at rate
+// percent. In tax_inclusive mode the tax is taken out of the price; in
+// tax_exclusive mode it is added to it. The tax is rounded to Places.
+func Compute(price, quantity, rate decimal.Decimal, mode generated.PriceMode) Amounts {
+	gross := price.Mul(quantity)
+	if mode == generated.PriceModeTaxInclusive {
+		tax := gross.Mul(rate).Div(hundred.Add(rate)).Round(Places)
+		return Amounts{Taxable: gross.Sub(tax), Tax: tax, Total: gross}
+	}
+	tax := gross.Mul(rate).Div(hundred).Round(Places)
+	return Amounts{Taxable: gross, Tax: tax, Total: gross.Add(tax)}
+}
+
+// PriceModeFor returns the price mode configured for an organization.
+func PriceModeFor(ctx context.Context, q *generated.Queries, organizationID uuid.UUID) (generated.PriceMode, error) {
+	mode, err := q.GetOrganizationPriceMode(ctx, organizationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrUnknownOrganization
+	}
+	if err != nil {
+		return "", fmt.Errorf("get price mode: %w", err)
+	}
+	return mode, nil
+}
+
+// Service manages tax configuration.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a tax Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Rates returns the tax rates of an organization by name.
+func (s *Service) Rates(ctx context.Context, organizationID uuid.UUID) ([]generated.TaxRate, error) {
+	return s.db.Queries().ListTaxRates(ctx, organizationID)
+}
+
+// CreateRate adds a tax rate of rate percent to an organization.
+func (s *Service) CreateRate(ctx context.Context, organizationID, userProfileID uuid.UUID, name string, rate decimal.Decimal) (generated.TaxRate, error) {
+	if name == "" || rate.IsNegative() {
+		return generated.TaxRate{}, ErrInvalidRate
+	}
+	var created generated.TaxRate
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		var err error
+		created, err = q.InsertTaxRate(ctx, generated.InsertTaxRateParams{
+			OrganizationID: organizationID,
+			Name:           name,
+			Rate:           rate,
+		})
+		if err != nil {
+			return fmt.Errorf("insert tax rate: %w", err)
+		}
+		return nil
+	})
+	return created, err
+}
+
+// UpdateRate renames a tax rate and changes its percentage. Sales recorded
+// earlier keep the rate they were charged at.
+func (s *Service) UpdateRate(ctx context.Context, organizationID, userProfileID, taxRateID uuid.UUID, name string, rate decimal.Decimal) (generated.TaxRate, error) {
+	if name == "" || rate.IsNegative() {
+		return generated.TaxRate{}, ErrInvalidRate
+	}
+	var updated generated.TaxRate
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		var err error
+		updated, err = q.UpdateTaxRate(ctx, generated.UpdateTaxRateParams{
+			Name:           name,
+			Rate:           rate,
+			TaxRateID:      taxRateID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRateNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update tax rate: %w", err)
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// SetDefaultRate makes a tax rate the organization's default. An invalid
+// taxRateID leaves the organization without a default, so products without
+// a rate of their own are not taxed.
+func (s *Service) SetDefaultRate(ctx context.Context, organizationID, userProfileID uuid.UUID, taxRateID uuid.NullUUID) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		if err := q.ClearDefaultTaxRate(ctx, organizationID); err != nil {
+			return fmt.Errorf("clear default tax rate: %w", err)
+		}
+		if !taxRateID.Valid {
+			return nil
+		}
+		_, err := q.SetDefaultTaxRate(ctx, generated.SetDefaultTaxRateParams{
+			TaxRateID:      taxRateID.UUID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRateNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("set default tax rate: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetProductRate sets the tax rate of a product. An invalid taxRateID makes
+// the product use the organization's default rate.
+func (s *Service) SetProductRate(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, taxRateID uuid.NullUUID) (generated.Product, error) {
+	var product generated.Product
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		branchUuid, err := q.GetTaxProductBranch(ctx, generated.GetTaxProductBranchParams{
+			ProductID:      productID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("product %s: %w", productID, ErrProductNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("get product: %w", err)
+		}
+		if err := actor.RequireBranchRole(branchUuid, productRoles...); err != nil {
+			return err
+		}
+		product, err = q.SetProductTaxRate(ctx, generated.SetProductTaxRateParams{
+			TaxRateID:      taxRateID,
+			ProductID:      productID,
+			OrganizationID: organizationID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// The product exists, so the rate does not
+			return fmt.Errorf("tax rate %s: %w", taxRateID.UUID, ErrRateNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("set product tax rate: %w", err)
+		}
+		return nil
+	})
+	return product, err
+}
+
+// SetPriceMode changes whether the organization's selling prices include
+// tax. Sales recorded earlier keep the mode they were recorded in.
+func (s *Service) SetPriceMode(ctx context.Context, organizationID, userProfileID uuid.UUID, mode generated.PriceMode) error {
+	switch mode {
+	case generated.PriceModeTaxExclusive, generated.PriceModeTaxInclusive:
+	default:
+		return fmt.Errorf("%q: %w", mode, ErrUnknownPriceMode)
+	}
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if err := requireAdmin(ctx, q, userProfileID, organizationID); err != nil {
+			return err
+		}
+		return q.SetOrganizationPriceMode(ctx, generated.SetOrganizationPriceModeParams{
+			ID:        organizationID,
+			PriceMode: mode,
+		})
+	})
+}
+
+// requireAdmin checks the user may change the organization's tax
+// configuration.
+func requireAdmin(ctx context.Context, q *generated.Queries, userProfileID, organizationID uuid.UUID) error {
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return err
+	}
+	if !actor.HasRole(adminRoles...) {
+		return fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+	}
+	return nil
+}
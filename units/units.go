@@ -0,0 +1,217 @@
+// Package units keeps the units of measure of an organization and converts
+// quantities between them.
+//
+// Stock is always held in a product's measurement_unit. Purchases and sales
+// may be entered in any unit that converts to it: a catalog unit with the
+// same base unit, such as g for a product kept in kg, or a packaging of the
+// product itself, such as a box of 12 pieces.
+package units
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sushan531/auth-sqlc/access"
+	"github.com/sushan531/auth-sqlc/generated"
+	"github.com/sushan531/auth-sqlc/store"
+)
+
+var (
+	// ErrNoConversion is returned when a unit does not convert to a product's measurement unit.
+	ErrNoConversion = errors.New("units: unit does not convert to the product's unit")
+	// ErrInvalidUnit is returned when a unit has no name or a factor that is not positive.
+	ErrInvalidUnit = errors.New("units: unit needs a name and a positive factor")
+	// ErrUnknownUnit is returned when a base unit is not in the organization's catalog.
+	ErrUnknownUnit = errors.New("units: unit not found")
+	// ErrProductNotFound is returned when a product does not exist in the organization.
+	ErrProductNotFound = errors.New("units: product not found")
+)
+
+// catalogRoles may add units to the organization's catalog.
+var catalogRoles = []access.Role{access.RoleAdmin}
+
+// productRoles may define the packaging of products in their branches.
+var productRoles = []access.Role{access.RoleAdmin, access.RoleBranchManager}
+
+// Factor returns how many of a product's measurement unit make one unit.
+func Factor(ctx context.Context, q *generated.Queries, productID uuid.UUID, unit string) (decimal.Decimal, error) {
+	factor, err := q.GetProductUnitFactor(ctx, generated.GetProductUnitFactorParams{
+		ProductID: productID,
+		Unit:      unit,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("get unit factor: %w", err)
+	}
+	if !factor.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%q for product %s: %w", unit, productID, ErrNoConversion)
+	}
+	return factor, nil
+}
+
+// CheckPurchaseUnits checks that every purchase line for an existing
+// product of a branch is entered in a unit that converts to the product's.
+// uniqueNames and lineUnits are the unique_name and unit of each line.
+func CheckPurchaseUnits(ctx context.Context, q *generated.Queries, branchUuid uuid.UUID, uniqueNames, lineUnits []string) error {
+	rows, err := q.ListUnconvertiblePurchaseUnits(ctx, generated.ListUnconvertiblePurchaseUnitsParams{
+		UniqueNames: uniqueNames,
+		Units:       lineUnits,
+		BranchUuid:  branchUuid,
+	})
+	if err != nil {
+		return fmt.Errorf("check purchase units: %w", err)
+	}
+	if len(rows) > 0 {
+		return fmt.Errorf("%q for %s: %w", rows[0].Unit, rows[0].UniqueName, ErrNoConversion)
+	}
+	return nil
+}
+
+// Service manages the unit catalog and product packaging.
+type Service struct {
+	db *store.DB
+}
+
+// NewService returns a units Service backed by db.
+func NewService(db *store.DB) *Service {
+	return &Service{db: db}
+}
+
+// Units returns the unit catalog of an organization, grouped by base unit.
+func (s *Service) Units(ctx context.Context, organizationID uuid.UUID) ([]generated.Unit, error) {
+	return s.db.Queries().ListUnits(ctx, organizationID)
+}
+
+// CreateUnit adds a unit that is factor of baseUnit, such as a dozen of 12
+// pieces. baseUnit may itself be defined in terms of another unit; the new
+// unit is stored against the underlying base unit. An empty baseUnit makes
+// a new base unit, and factor is then ignored.
+func (s *Service) CreateUnit(ctx context.Context, organizationID, userProfileID uuid.UUID, name, baseUnit string, factor decimal.Decimal) (generated.Unit, error) {
+	name = strings.TrimSpace(name)
+	baseUnit = strings.TrimSpace(baseUnit)
+	if baseUnit == "" || baseUnit == name {
+		baseUnit, factor = name, decimal.NewFromInt(1)
+	}
+	if name == "" || !factor.IsPositive() {
+		return generated.Unit{}, ErrInvalidUnit
+	}
+	var unit generated.Unit
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+		if err != nil {
+			return err
+		}
+		if !actor.HasRole(catalogRoles...) {
+			return fmt.Errorf("role %q: %w", actor.Role, access.ErrForbidden)
+		}
+		if baseUnit != name {
+			base, err := q.GetUnit(ctx, generated.GetUnitParams{OrganizationID: organizationID, Name: baseUnit})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%q: %w", baseUnit, ErrUnknownUnit)
+			}
+			if err != nil {
+				return fmt.Errorf("get unit: %w", err)
+			}
+			baseUnit, factor = base.BaseUnit, factor.Mul(base.Factor)
+		}
+		unit, err = q.InsertUnit(ctx, generated.InsertUnitParams{
+			OrganizationID: organizationID,
+			Name:           name,
+			BaseUnit:       baseUnit,
+			Factor:         factor,
+		})
+		if err != nil {
+			return fmt.Errorf("insert unit: %w", err)
+		}
+		return nil
+	})
+	return unit, err
+}
+
+// ProductUnits returns the packaging defined for a product.
+func (s *Service) ProductUnits(ctx context.Context, productID uuid.UUID) ([]generated.ProductUnit, error) {
+	return s.db.Queries().ListProductUnits(ctx, productID)
+}
+
+// SetProductUnit defines unitName as factor of a product's measurement
+// unit, replacing an earlier definition. It takes precedence over the
+// catalog for that product.
+func (s *Service) SetProductUnit(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, unitName string, factor decimal.Decimal) (generated.ProductUnit, error) {
+	unitName = strings.TrimSpace(unitName)
+	if unitName == "" || !factor.IsPositive() {
+		return generated.ProductUnit{}, ErrInvalidUnit
+	}
+	var unit generated.ProductUnit
+	err := s.db.WithTx(ctx, func(q *generated.Queries) error {
+		product, err := authorizeProduct(ctx, q, organizationID, userProfileID, productID)
+		if err != nil {
+			return err
+		}
+		if unitName == product.MeasurementUnit {
+			return fmt.Errorf("%q is the product's own unit: %w", unitName, ErrInvalidUnit)
+		}
+		unit, err = q.UpsertProductUnit(ctx, generated.UpsertProductUnitParams{
+			ProductID: productID,
+			UnitName:  unitName,
+			Factor:    factor,
+		})
+		if err != nil {
+			return fmt.Errorf("upsert product unit: %w", err)
+		}
+		return nil
+	})
+	return unit, err
+}
+
+// RemoveProductUnit removes a packaging of a product. Lines already entered
+// in it keep their converted quantities.
+func (s *Service) RemoveProductUnit(ctx context.Context, organizationID, userProfileID, productID uuid.UUID, unitName string) error {
+	return s.db.WithTx(ctx, func(q *generated.Queries) error {
+		if _, err := authorizeProduct(ctx, q, organizationID, userProfileID, productID); err != nil {
+			return err
+		}
+		n, err := q.DeleteProductUnit(ctx, generated.DeleteProductUnitParams{
+			ProductID: productID,
+			UnitName:  unitName,
+		})
+		if err != nil {
+			return fmt.Errorf("delete product unit: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("%q: %w", unitName, ErrUnknownUnit)
+		}
+		return nil
+	})
+}
+
+// Convert returns quantity of unit in a product's measurement unit.
+func (s *Service) Convert(ctx context.Context, productID uuid.UUID, unit string, quantity decimal.Decimal) (decimal.Decimal, error) {
+	factor, err := Factor(ctx, s.db.Queries(), productID, unit)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return quantity.Mul(factor), nil
+}
+
+// authorizeProduct checks the user may change the packaging of a product.
+func authorizeProduct(ctx context.Context, q *generated.Queries, organizationID, userProfileID, productID uuid.UUID) (generated.GetProductMeasurementUnitRow, error) {
+	actor, err := access.LoadActor(ctx, q, userProfileID, organizationID)
+	if err != nil {
+		return generated.GetProductMeasurementUnitRow{}, err
+	}
+	product, err := q.GetProductMeasurementUnit(ctx, generated.GetProductMeasurementUnitParams{
+		ProductID:      productID,
+		OrganizationID: organizationID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return product, ErrProductNotFound
+	}
+	if err != nil {
+		return product, fmt.Errorf("get product: %w", err)
+	}
+	return product, actor.RequireBranchRole(product.BranchUuid, productRoles...)
+}